@@ -0,0 +1,196 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablecli
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// BorderLine holds the glyphs of a horizontal border line. Lines with an
+// empty Horizontal glyph are not drawn.
+type BorderLine struct {
+	Left, Horizontal, Cross, Right string
+}
+
+// BorderStyle holds the glyphs used to draw the borders of a boxed table.
+// Top is drawn above the headers, Header below them and above the footers,
+// Middle between rows when LineSeparator is set and Bottom below the last
+// row. A Header line with an empty Horizontal glyph falls back to Middle.
+// Left, Vertical and Right are drawn before, between and after the cells
+// of each line.
+type BorderStyle struct {
+	Top, Header, Middle, Bottom BorderLine
+	Left, Vertical, Right       string
+}
+
+var (
+	// BorderASCII is the default style, drawn with +, - and |.
+	BorderASCII = BorderStyle{
+		Top:      BorderLine{"+", "-", "+", "+"},
+		Middle:   BorderLine{"+", "-", "+", "+"},
+		Bottom:   BorderLine{"+", "-", "+", "+"},
+		Left:     "|",
+		Vertical: "|",
+		Right:    "|",
+	}
+	// BorderSingle uses single line box drawing characters, the style used
+	// by UseUTF8Borders.
+	BorderSingle = BorderStyle{
+		Top:      BorderLine{"┌", "─", "┬", "┐"},
+		Middle:   BorderLine{"├", "─", "┼", "┤"},
+		Bottom:   BorderLine{"└", "─", "┴", "┘"},
+		Left:     "│",
+		Vertical: "│",
+		Right:    "│",
+	}
+	// BorderRounded is BorderSingle with rounded corners.
+	BorderRounded = BorderStyle{
+		Top:      BorderLine{"╭", "─", "┬", "╮"},
+		Middle:   BorderLine{"├", "─", "┼", "┤"},
+		Bottom:   BorderLine{"╰", "─", "┴", "╯"},
+		Left:     "│",
+		Vertical: "│",
+		Right:    "│",
+	}
+	// BorderDouble uses double line box drawing characters.
+	BorderDouble = BorderStyle{
+		Top:      BorderLine{"╔", "═", "╦", "╗"},
+		Middle:   BorderLine{"╠", "═", "╬", "╣"},
+		Bottom:   BorderLine{"╚", "═", "╩", "╝"},
+		Left:     "║",
+		Vertical: "║",
+		Right:    "║",
+	}
+	// BorderHeavy uses heavy line box drawing characters.
+	BorderHeavy = BorderStyle{
+		Top:      BorderLine{"┏", "━", "┳", "┓"},
+		Middle:   BorderLine{"┣", "━", "╋", "┫"},
+		Bottom:   BorderLine{"┗", "━", "┻", "┛"},
+		Left:     "┃",
+		Vertical: "┃",
+		Right:    "┃",
+	}
+	// BorderDashed is BorderSingle with dashed lines.
+	BorderDashed = BorderStyle{
+		Top:      BorderLine{"┌", "┄", "┬", "┐"},
+		Middle:   BorderLine{"├", "┄", "┼", "┤"},
+		Bottom:   BorderLine{"└", "┄", "┴", "┘"},
+		Left:     "┆",
+		Vertical: "┆",
+		Right:    "┆",
+	}
+	// BorderASCIICompact only draws the lines between columns and below
+	// the headers, like psql.
+	BorderASCIICompact = BorderStyle{
+		Middle:   BorderLine{"", "-", "+", ""},
+		Vertical: "|",
+	}
+	// BorderMarkdown draws tables that look like Markdown pipe tables.
+	BorderMarkdown = BorderStyle{
+		Middle:   BorderLine{"|", "-", "|", "|"},
+		Left:     "|",
+		Vertical: "|",
+		Right:    "|",
+	}
+	// BorderNone draws no borders at all, only aligned columns.
+	BorderNone = BorderStyle{}
+)
+
+// borderStyles maps the names accepted by ParseBorderStyle to the presets.
+var borderStyles = map[string]*BorderStyle{
+	"ascii":    &BorderASCII,
+	"single":   &BorderSingle,
+	"rounded":  &BorderRounded,
+	"double":   &BorderDouble,
+	"heavy":    &BorderHeavy,
+	"dashed":   &BorderDashed,
+	"compact":  &BorderASCIICompact,
+	"markdown": &BorderMarkdown,
+	"none":     &BorderNone,
+}
+
+// BorderStyleNames returns the sorted names of the border style presets.
+func BorderStyleNames() []string {
+	names := make([]string, 0, len(borderStyles))
+	for name := range borderStyles {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// ParseBorderStyle returns the border style preset with the given name,
+// ignoring case, e.g. "rounded" or "double". See BorderStyleNames.
+func ParseBorderStyle(name string) (BorderStyle, error) {
+	style, ok := borderStyles[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return BorderStyle{}, fmt.Errorf("unknown border style %q, must be one of: %s", name, strings.Join(BorderStyleNames(), ", "))
+	}
+	return *style, nil
+}
+
+// SetBorderStyle sets the BorderStyle of TableConfig from the name of a
+// preset.
+func SetBorderStyle(name string) error {
+	return TableConfig.SetBorderStyle(name)
+}
+
+// SetBorderStyle sets the BorderStyle of o from the name of a preset.
+// Unknown names leave o unchanged.
+func (o *RenderOptions) SetBorderStyle(name string) error {
+	style, err := ParseBorderStyle(name)
+	if err != nil {
+		return err
+	}
+	o.BorderStyle = &style
+	return nil
+}
+
+// borderStyle returns the BorderStyle used to draw tables with o, without
+// the borders disabled by NoOuterBorder and NoColumnSeparators.
+func (o *RenderOptions) borderStyle() BorderStyle {
+	var style BorderStyle
+	switch {
+	case o.BorderStyle != nil:
+		style = *o.BorderStyle
+	case o.UseUTF8Borders:
+		style = BorderSingle
+	default:
+		style = BorderASCII
+	}
+	if style.Header.Horizontal == "" {
+		style.Header = style.Middle
+	}
+	lines := []*BorderLine{&style.Top, &style.Header, &style.Middle, &style.Bottom}
+	if o.NoOuterBorder {
+		style.Top, style.Bottom = BorderLine{}, BorderLine{}
+		style.Left, style.Right = "", ""
+		for _, line := range lines {
+			line.Left, line.Right = "", ""
+		}
+	}
+	if o.NoColumnSeparators {
+		style.Vertical = ""
+		for _, line := range lines {
+			line.Cross = ""
+		}
+	}
+	return style
+}
+
+// line returns the BorderLine drawn at pos.
+func (s BorderStyle) line(pos separatorPosition) BorderLine {
+	switch pos {
+	case sepTop:
+		return s.Top
+	case sepHeader, sepFooter:
+		return s.Header
+	case sepBottom:
+		return s.Bottom
+	}
+	return s.Middle
+}
@@ -0,0 +1,170 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablecli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBorderStylePresets(t *testing.T) {
+	tests := []struct {
+		style    BorderStyle
+		expected string
+	}{
+		{BorderASCII, "+------+-------+\n| Name | Units |\n+------+-------+\n| app1 | 2     |\n+------+-------+\n"},
+		{BorderSingle, "┌──────┬───────┐\n│ Name │ Units │\n├──────┼───────┤\n│ app1 │ 2     │\n└──────┴───────┘\n"},
+		{BorderRounded, "╭──────┬───────╮\n│ Name │ Units │\n├──────┼───────┤\n│ app1 │ 2     │\n╰──────┴───────╯\n"},
+		{BorderDouble, "╔══════╦═══════╗\n║ Name ║ Units ║\n╠══════╬═══════╣\n║ app1 ║ 2     ║\n╚══════╩═══════╝\n"},
+		{BorderHeavy, "┏━━━━━━┳━━━━━━━┓\n┃ Name ┃ Units ┃\n┣━━━━━━╋━━━━━━━┫\n┃ app1 ┃ 2     ┃\n┗━━━━━━┻━━━━━━━┛\n"},
+		{BorderDashed, "┌┄┄┄┄┄┄┬┄┄┄┄┄┄┄┐\n┆ Name ┆ Units ┆\n├┄┄┄┄┄┄┼┄┄┄┄┄┄┄┤\n┆ app1 ┆ 2     ┆\n└┄┄┄┄┄┄┴┄┄┄┄┄┄┄┘\n"},
+		{BorderASCIICompact, " Name | Units\n------+-------\n app1 | 2\n"},
+		{BorderMarkdown, "| Name | Units |\n|------|-------|\n| app1 | 2     |\n"},
+		{BorderNone, " Name  Units\n app1  2\n"},
+	}
+	for _, tt := range tests {
+		table := NewTable()
+		table.Options = &RenderOptions{BorderStyle: &tt.style}
+		table.Headers = Row{"Name", "Units"}
+		table.AddRow(Row{"app1", "2"})
+		assert.Equal(t, tt.expected, table.String())
+	}
+}
+
+func TestBorderStyleCustom(t *testing.T) {
+	style := BorderStyle{
+		Top:      BorderLine{"*", "=", "*", "*"},
+		Bottom:   BorderLine{"*", "=", "*", "*"},
+		Left:     "[",
+		Vertical: ":",
+		Right:    "]",
+	}
+	table := NewTable()
+	table.Options = &RenderOptions{BorderStyle: &style}
+	table.Headers = Row{"Name", "Units"}
+	table.AddRow(Row{"app1", "2"})
+	expected := "*======*=======*\n[ Name : Units ]\n[ app1 : 2     ]\n*======*=======*\n"
+	assert.Equal(t, expected, table.String())
+}
+
+func TestBorderStyleLineSeparator(t *testing.T) {
+	style := BorderRounded
+	table := NewTable()
+	table.Options = &RenderOptions{BorderStyle: &style}
+	table.LineSeparator = true
+	table.Headers = Row{"Name", "Units"}
+	table.AddRow(Row{"app1", "2"})
+	table.AddRow(Row{"app2", "10"})
+	expected := `╭──────┬───────╮
+│ Name │ Units │
+├──────┼───────┤
+│ app1 │ 2     │
+├──────┼───────┤
+│ app2 │ 10    │
+╰──────┴───────╯
+`
+	assert.Equal(t, expected, table.String())
+}
+
+func TestBorderStyleExpanded(t *testing.T) {
+	style := BorderDouble
+	table := NewTable()
+	table.Options = &RenderOptions{BorderStyle: &style, Expanded: true}
+	table.Headers = Row{"Name", "Units"}
+	table.AddRow(Row{"app1", "2"})
+	expected := "═[ RECORD 1 ]\nName  ║ app1\nUnits ║ 2\n"
+	assert.Equal(t, expected, table.String())
+}
+
+func TestParseBorderStyle(t *testing.T) {
+	style, err := ParseBorderStyle("Rounded")
+	assert.NoError(t, err)
+	assert.Equal(t, BorderRounded, style)
+	style, err = ParseBorderStyle("none")
+	assert.NoError(t, err)
+	assert.Equal(t, BorderNone, style)
+	_, err = ParseBorderStyle("fancy")
+	assert.EqualError(t, err, `unknown border style "fancy", must be one of: ascii, compact, dashed, double, heavy, markdown, none, rounded, single`)
+}
+
+func TestSetBorderStyle(t *testing.T) {
+	opts := RenderOptions{UseUTF8Borders: true}
+	assert.Equal(t, BorderSingle.Vertical, opts.borderStyle().Vertical)
+	assert.NoError(t, opts.SetBorderStyle("heavy"))
+	assert.Equal(t, BorderHeavy, *opts.BorderStyle)
+	assert.Error(t, opts.SetBorderStyle("fancy"))
+	assert.Equal(t, BorderHeavy, *opts.BorderStyle)
+
+	opts.BorderStyle.Vertical = "!"
+	assert.Equal(t, "┃", BorderHeavy.Vertical)
+	assert.Equal(t, BorderASCII.Vertical, (&RenderOptions{}).borderStyle().Vertical)
+}
+
+func TestSetBorderStyleTableConfig(t *testing.T) {
+	defer func() { TableConfig.BorderStyle = nil }()
+	assert.NoError(t, SetBorderStyle("markdown"))
+	table := NewTable()
+	table.Headers = Row{"Name"}
+	table.AddRow(Row{"app1"})
+	assert.Equal(t, "| Name |\n|------|\n| app1 |\n", table.String())
+}
+
+func TestBorderStyleHeaderLine(t *testing.T) {
+	style := BorderSingle
+	style.Header = BorderLine{"╞", "═", "╪", "╡"}
+	table := NewTable()
+	table.Options = &RenderOptions{BorderStyle: &style}
+	table.LineSeparator = true
+	table.Headers = Row{"Name", "Units"}
+	table.AddRow(Row{"app1", "2"})
+	table.AddRow(Row{"app2", "10"})
+	expected := `┌──────┬───────┐
+│ Name │ Units │
+╞══════╪═══════╡
+│ app1 │ 2     │
+├──────┼───────┤
+│ app2 │ 10    │
+└──────┴───────┘
+`
+	assert.Equal(t, expected, table.String())
+}
+
+func TestNoOuterBorder(t *testing.T) {
+	style := BorderSingle
+	table := NewTable()
+	table.Options = &RenderOptions{BorderStyle: &style, NoOuterBorder: true}
+	table.Headers = Row{"Name", "Units"}
+	table.AddRow(Row{"app1", "2"})
+	expected := " Name │ Units\n──────┼───────\n app1 │ 2\n"
+	assert.Equal(t, expected, table.String())
+}
+
+func TestNoColumnSeparators(t *testing.T) {
+	style := BorderASCII
+	table := NewTable()
+	table.Options = &RenderOptions{BorderStyle: &style, NoColumnSeparators: true}
+	table.Headers = Row{"Name", "Units"}
+	table.AddRow(Row{"app1", "2"})
+	expected := "+-------------+\n| Name  Units |\n+-------------+\n| app1  2     |\n+-------------+\n"
+	assert.Equal(t, expected, table.String())
+}
+
+func TestMinimalTableKeepsWrapping(t *testing.T) {
+	table := NewTable()
+	table.Options = &RenderOptions{
+		MaxTTYWidth:        20,
+		NoOuterBorder:      true,
+		NoColumnSeparators: true,
+	}
+	table.Headers = Row{"Name", "Description"}
+	table.AddRow(Row{"app1", "a long description"})
+	expected := ` Name  Description
+--------------------
+ app1  a long     ↵
+       description
+`
+	assert.Equal(t, expected, table.String())
+}
@@ -12,24 +12,45 @@ import (
 	"github.com/fatih/color"
 )
 
+// SetBorderColorByString sets the BorderColorFunc of TableConfig from a
+// color name (e.g. "red", "hi-blue") or a hex color (e.g. "#FF8800").
 func SetBorderColorByString(tableColor string) {
+	TableConfig.SetBorderColorByString(tableColor)
+}
+
+// SetBorderColorByString sets the BorderColorFunc of o from a color name
+// or a hex color. Invalid colors leave o unchanged.
+func (o *RenderOptions) SetBorderColorByString(tableColor string) {
 	var tblColor *color.Color
+	var cssColor string
 
 	if fgColor := colorMap[tableColor]; fgColor != 0 {
 		tblColor = color.New(fgColor)
+		cssColor = ansiColor(attributeColorIndex(fgColor))
 	} else if strings.HasPrefix(tableColor, "#") {
 		c, err := parseHexColor(tableColor)
 
 		if err == nil {
 			tblColor = color.RGB(int(c.R), int(c.G), int(c.B))
+			cssColor = fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
 		}
 	}
 
 	if tblColor != nil {
-		TableConfig.BorderColorFunc = func(s string) string {
+		o.BorderColorFunc = func(s string) string {
 			return tblColor.Sprint(s)
 		}
+		o.borderCSS = cssColor
+	}
+}
+
+// attributeColorIndex returns the palette index of a foreground color
+// attribute, 0-7 for normal and 8-15 for high intensity colors.
+func attributeColorIndex(attr color.Attribute) int {
+	if attr >= color.FgHiBlack {
+		return int(attr-color.FgHiBlack) + 8
 	}
+	return int(attr - color.FgBlack)
 }
 
 func parseHexColor(s string) (c stdColor.RGBA, err error) {
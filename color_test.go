@@ -95,3 +95,13 @@ func TestSetBorderColorByString(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderOptionsSetBorderColorByString(t *testing.T) {
+	opts := RenderOptions{}
+	opts.SetBorderColorByString("#FF0000")
+	assert.NotNil(t, opts.BorderColorFunc)
+	assert.Nil(t, TableConfig.BorderColorFunc)
+	opts = RenderOptions{}
+	opts.SetBorderColorByString("invalid-color")
+	assert.Nil(t, opts.BorderColorFunc)
+}
@@ -0,0 +1,227 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablecli
+
+import "strings"
+
+// Alignment is the horizontal alignment of the cells in a column.
+type Alignment int
+
+const (
+	// AlignDefault leaves the alignment up to the renderer, which is left
+	// aligned for the boxed and tabwriter-like renderers.
+	AlignDefault Alignment = iota
+	AlignLeft
+	AlignRight
+	AlignCenter
+	// AlignDecimal lines up the decimal points of the numbers in a column,
+	// right aligning the resulting block.
+	AlignDecimal
+)
+
+// ColumnSpec holds settings for a single column of a Table.
+type ColumnSpec struct {
+	Align Alignment
+	// HeaderAlign is the alignment of the column header. AlignDefault uses
+	// Align, except for AlignDecimal which right aligns the header.
+	HeaderAlign Alignment
+	// ShortHeader is shown instead of the header when the column is too
+	// narrow for it, before resorting to wrapping the header.
+	ShortHeader string
+
+	// MinWidth is the minimum width of the column. Narrower columns are
+	// padded and wider ones are never wrapped below it to fit the terminal.
+	MinWidth int
+	// MaxWidth is the maximum width of the column, cells wider than it are
+	// always wrapped.
+	MaxWidth int
+	// Width fixes the width of the column, as if both MinWidth and MaxWidth
+	// were set to it.
+	Width int
+	// WidthPercent fixes the width of the column to a percentage of the
+	// terminal width left after borders and padding. It is ignored when the
+	// terminal width is unknown and takes precedence over Width.
+	WidthPercent int
+
+	// BreakChars, WrapMarker and NoWrapMarker override the RenderOptions
+	// fields of the same name for this column.
+	BreakChars   string
+	WrapMarker   string
+	NoWrapMarker bool
+	// BalancedWrap enables RenderOptions.BalancedWrap for this column.
+	BalancedWrap bool
+	// Overflow chooses between wrapping and truncating cells wider than
+	// the column.
+	Overflow Overflow
+
+	// Aggregate adds a footer row with the given value computed from the
+	// cells of the column.
+	Aggregate Aggregate
+	// FooterLabel is written in that footer row when the column has no
+	// Aggregate, e.g. "Total".
+	FooterLabel string
+}
+
+// Column returns the spec of the column at index i, growing Columns when
+// needed, so it can be changed in place:
+//
+//	table.Column(1).Align = tablecli.AlignRight
+func (t *Table) Column(i int) *ColumnSpec {
+	if i >= len(t.Columns) {
+		columns := make([]ColumnSpec, i+1)
+		copy(columns, t.Columns)
+		t.Columns = columns
+	}
+	return &t.Columns[i]
+}
+
+func (t *Table) columnSpec(i int) ColumnSpec {
+	if i < len(t.Columns) {
+		return t.Columns[i]
+	}
+	return ColumnSpec{}
+}
+
+// widthLimits returns the minimum and maximum width of each of the n
+// columns according to their specs, where contentWidth is the terminal
+// width available for cells. A zero maximum means no limit.
+func (t *Table) widthLimits(n, contentWidth int) (mins, maxs []int) {
+	mins = make([]int, n)
+	maxs = make([]int, n)
+	for i := range n {
+		spec := t.columnSpec(i)
+		minWidth, maxWidth := spec.MinWidth, spec.MaxWidth
+		fixed := spec.Width
+		if spec.WidthPercent > 0 && contentWidth > 0 {
+			fixed = contentWidth * spec.WidthPercent / 100
+		}
+		if fixed > 0 {
+			minWidth, maxWidth = fixed, fixed
+		}
+		if maxWidth > 0 {
+			// textWrapper needs room for at least one rune and the
+			// wrap marker.
+			maxWidth = max(maxWidth, 2)
+			minWidth = min(minWidth, maxWidth)
+		}
+		mins[i], maxs[i] = max(minWidth, 0), maxWidth
+	}
+	return mins, maxs
+}
+
+func (spec ColumnSpec) headerAlign() Alignment {
+	switch {
+	case spec.HeaderAlign != AlignDefault:
+		return spec.HeaderAlign
+	case spec.Align == AlignDecimal:
+		return AlignRight
+	}
+	return spec.Align
+}
+
+// decimalWidth holds the widths of the integer and fractional parts, the
+// latter including the decimal point, of the widest numbers in a column.
+type decimalWidth struct {
+	integer, fraction int
+}
+
+func (d decimalWidth) width() int {
+	return d.integer + d.fraction
+}
+
+func splitDecimal(line string) (integer, fraction string) {
+	if idx := strings.IndexByte(line, '.'); idx >= 0 {
+		return line[:idx], line[idx:]
+	}
+	return line, ""
+}
+
+// decimalWidths returns the decimalWidth of every column aligned with
+// AlignDecimal, looking at every line of every cell in rows.
+func (t *Table) decimalWidths(rows rowSlice, n int) []decimalWidth {
+	widths := make([]decimalWidth, n)
+	for i := range n {
+		if t.columnSpec(i).Align != AlignDecimal {
+			continue
+		}
+		for _, row := range rows {
+			if i >= len(row) || row[i] == Spanned || cellSpan(row, i) > 1 {
+				continue
+			}
+			for _, line := range strings.Split(row[i], "\n") {
+				integer, fraction := splitDecimal(line)
+				widths[i].integer = max(widths[i].integer, displayWidth(integer))
+				widths[i].fraction = max(widths[i].fraction, displayWidth(fraction))
+			}
+		}
+	}
+	return widths
+}
+
+// cellAligner computes the padding around each line of a cell according
+// to the alignment of its column.
+type cellAligner struct {
+	aligns   []Alignment
+	decimals []decimalWidth
+}
+
+// newAligner returns a cellAligner for the n columns of rows. When header
+// is true the header alignment is used instead.
+func (t *Table) newAligner(rows rowSlice, n int, header bool) *cellAligner {
+	a := &cellAligner{aligns: make([]Alignment, n)}
+	for i := range n {
+		if header {
+			a.aligns[i] = t.columnSpec(i).headerAlign()
+		} else {
+			a.aligns[i] = t.columnSpec(i).Align
+		}
+	}
+	if !header {
+		a.decimals = t.decimalWidths(rows, n)
+	}
+	return a
+}
+
+// padding returns the number of spaces to write before and after line so
+// it fills width in the given column.
+func (a *cellAligner) padding(column int, line string, width int) (left, right int) {
+	var align Alignment
+	if column < len(a.aligns) {
+		align = a.aligns[column]
+	}
+	free := max(width-displayWidth(line), 0)
+	switch align {
+	case AlignRight:
+		return free, 0
+	case AlignCenter:
+		return free / 2, free - free/2
+	case AlignDecimal:
+		if line == "" {
+			return width, 0
+		}
+		dec := a.decimals[column]
+		integer, fraction := splitDecimal(line)
+		right = max(dec.fraction-displayWidth(fraction), 0)
+		left = max(width-dec.width(), 0) + max(dec.integer-displayWidth(integer), 0)
+		if left+right > free {
+			return free, 0
+		}
+		return left, right
+	}
+	return 0, free
+}
+
+// rowsSize returns the column sizes of rows, widened to fit the numbers
+// of decimal aligned columns, the headers fitted to widths and the cells
+// spanning several columns.
+func (t *Table) rowsSize(opts *RenderOptions, rows rowSlice, widths []int) []int {
+	headers := t.fitHeaders(opts, widths)
+	sizes := columnsSize(headers, rows)
+	for i, dec := range t.decimalWidths(rows, len(sizes)) {
+		sizes[i] = max(sizes[i], dec.width())
+	}
+	opts.widenForSpans(sizes, append(rowSlice{headers}, rows...)...)
+	return sizes
+}
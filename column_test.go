@@ -0,0 +1,270 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablecli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColumn(t *testing.T) {
+	table := NewTable()
+	table.Column(2).Align = AlignRight
+	assert.Len(t, table.Columns, 3)
+	assert.Equal(t, AlignRight, table.Columns[2].Align)
+	table.Column(0).Align = AlignCenter
+	assert.Len(t, table.Columns, 3)
+	assert.Equal(t, []ColumnSpec{{Align: AlignCenter}, {}, {Align: AlignRight}}, table.Columns)
+}
+
+func TestColumnSpecOutOfRange(t *testing.T) {
+	table := NewTable()
+	table.Column(0).Align = AlignRight
+	assert.Equal(t, ColumnSpec{Align: AlignRight}, table.columnSpec(0))
+	assert.Equal(t, ColumnSpec{}, table.columnSpec(5))
+}
+
+func TestWidthLimits(t *testing.T) {
+	table := NewTable()
+	table.Column(0).MinWidth = 4
+	table.Column(1).MaxWidth = 10
+	table.Column(2).Width = 6
+	table.Column(3).WidthPercent = 50
+	table.Column(3).Width = 6
+	table.Column(4).MaxWidth = 1
+	mins, maxs := table.widthLimits(6, 40)
+	assert.Equal(t, []int{4, 0, 6, 20, 0, 0}, mins)
+	assert.Equal(t, []int{0, 10, 6, 20, 2, 0}, maxs)
+	mins, maxs = table.widthLimits(6, 0)
+	assert.Equal(t, []int{4, 0, 6, 6, 0, 0}, mins)
+	assert.Equal(t, []int{0, 10, 6, 6, 2, 0}, maxs)
+}
+
+func TestColumnMaxWidth(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name", "Description"}
+	table.Column(1).MaxWidth = 12
+	table.AddRow(Row{"app1", "a long description of the app"})
+	expected := `+------+--------------+
+| Name | Description  |
++------+--------------+
+| app1 | a long     ↵ |
+|      | description↵ |
+|      | of the app   |
++------+--------------+
+`
+	assert.Equal(t, expected, table.String())
+	assert.Equal(t, Row{"app1", "a long description of the app"}, table.rows[0])
+}
+
+func TestColumnFixedWidth(t *testing.T) {
+	table := NewTable()
+	table.Column(0).Width = 6
+	table.Column(1).Width = 3
+	table.AddRow(Row{"app1", "abcdef"})
+	expected := `+--------+-----+
+| app1   | ab↵ |
+|        | cd↵ |
+|        | ef  |
++--------+-----+
+`
+	assert.Equal(t, expected, table.String())
+}
+
+func TestColumnMinWidth(t *testing.T) {
+	table := NewTable()
+	table.Column(1).MinWidth = 5
+	table.AddRow(Row{"1", "ab"})
+	_, sizes := table.resizeLargestColumn(&RenderOptions{}, 0)
+	assert.Equal(t, []int{1, 5}, sizes)
+	expected := "+---+-------+\n| 1 | ab    |\n+---+-------+\n"
+	assert.Equal(t, expected, table.String())
+}
+
+func TestColumnMinWidthIsNeverWrapped(t *testing.T) {
+	table := NewTable()
+	table.Column(0).MinWidth = 36
+	table.AddRow(Row{"0d3a3a52-8a71-4d8e-9e7b-3d3b2f1e8c7a", "short", "a description"})
+	rows, sizes := table.resizeLargestColumn(&RenderOptions{}, 56)
+	assert.Equal(t, []int{36, 5, 5}, sizes)
+	assert.Equal(t, "0d3a3a52-8a71-4d8e-9e7b-3d3b2f1e8c7a", rows[0][0])
+	assert.Equal(t, "a   ↵\ndesc↵\nript↵\nion", rows[0][2])
+}
+
+func TestColumnMinWidthProportionalShrink(t *testing.T) {
+	table := NewTable()
+	table.Column(0).MinWidth = 36
+	table.AddRow(Row{"0d3a3a52-8a71-4d8e-9e7b-3d3b2f1e8c7a", "a description"})
+	rows, sizes := table.shrinkColumns(&RenderOptions{}, 50)
+	assert.Equal(t, []int{36, 7}, sizes)
+	assert.Equal(t, "0d3a3a52-8a71-4d8e-9e7b-3d3b2f1e8c7a", rows[0][0])
+}
+
+func TestColumnWidthPercent(t *testing.T) {
+	table := NewTable()
+	table.Column(0).WidthPercent = 50
+	table.Column(1).WidthPercent = 50
+	table.AddRow(Row{"abc", "abcdefghijklmnopqrstuvwxyz"})
+	expected := `+------------+------------+
+| abc        | abcdefghi↵ |
+|            | jklmnopqr↵ |
+|            | stuvwxyz   |
++------------+------------+
+`
+	assert.Equal(t, expected, table.StringWithOptions(RenderOptions{MaxTTYWidth: 27}))
+	expected = "+-----+----------------------------+\n| abc | abcdefghijklmnopqrstuvwxyz |\n+-----+----------------------------+\n"
+	assert.Equal(t, expected, table.StringWithOptions(RenderOptions{}))
+}
+
+func TestColumnMaxWidthTabWriter(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name", "Description", "Units"}
+	table.Column(1).MaxWidth = 12
+	table.AddRow(Row{"app1", "a long description", "2"})
+	table.AddRow(Row{"app2", "short", "3"})
+	expected := "NAME   DESCRIPTION    UNITS\n" +
+		"app1   a long     ↵   2\n" +
+		"       description    \n" +
+		"app2   short          3\n"
+	assert.Equal(t, expected, table.StringWithOptions(RenderOptions{UseTabWriter: true}))
+}
+
+func TestColumnMinWidthTabWriter(t *testing.T) {
+	table := NewTable()
+	table.Column(0).MinWidth = 6
+	table.AddRow(Row{"app1", "2"})
+	assert.Equal(t, "app1     2\n", table.StringWithOptions(RenderOptions{UseTabWriter: true}))
+}
+
+func TestColumnAlignment(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name", "Units", "Status"}
+	table.Column(1).Align = AlignRight
+	table.Column(2).Align = AlignCenter
+	table.AddRow(Row{"app1", "2", "ok"})
+	table.AddRow(Row{"app2", "10", "error"})
+	expected := `+------+-------+--------+
+| Name | Units | Status |
++------+-------+--------+
+| app1 |     2 |   ok   |
+| app2 |    10 | error  |
++------+-------+--------+
+`
+	assert.Equal(t, expected, table.String())
+}
+
+func TestColumnHeaderAlignment(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name", "Units"}
+	table.Column(0).HeaderAlign = AlignCenter
+	table.Column(1).Align = AlignRight
+	table.Column(1).HeaderAlign = AlignLeft
+	table.AddRow(Row{"my-app", "2"})
+	expected := `+--------+-------+
+|  Name  | Units |
++--------+-------+
+| my-app |     2 |
++--------+-------+
+`
+	assert.Equal(t, expected, table.String())
+}
+
+func TestColumnDecimalAlignment(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name", "Cost"}
+	table.Column(1).Align = AlignDecimal
+	table.AddRow(Row{"a", "1.5"})
+	table.AddRow(Row{"b", "100"})
+	table.AddRow(Row{"c", "12.25"})
+	table.AddRow(Row{"d", ""})
+	expected := `+------+--------+
+| Name |   Cost |
++------+--------+
+| a    |   1.5  |
+| b    | 100    |
+| c    |  12.25 |
+| d    |        |
++------+--------+
+`
+	assert.Equal(t, expected, table.String())
+}
+
+func TestColumnDecimalAlignmentWiderHeader(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Total cost"}
+	table.Column(0).Align = AlignDecimal
+	table.AddRow(Row{"1.5"})
+	table.AddRow(Row{withColor("10.25")})
+	expected := "+------------+\n" +
+		"| Total cost |\n" +
+		"+------------+\n" +
+		"|       1.5  |\n" +
+		"|      " + withColor("10.25") + " |\n" +
+		"+------------+\n"
+	assert.Equal(t, expected, table.String())
+}
+
+func TestColumnAlignmentMultiline(t *testing.T) {
+	table := NewTable()
+	table.Column(0).Align = AlignRight
+	table.Column(1).Align = AlignDecimal
+	table.AddRow(Row{"a\nbbb", "1.5\n10"})
+	expected := `+-----+------+
+|   a |  1.5 |
+| bbb | 10   |
++-----+------+
+`
+	assert.Equal(t, expected, table.String())
+}
+
+func TestColumnAlignmentWrapped(t *testing.T) {
+	table := NewTable()
+	table.Column(1).Align = AlignRight
+	table.AddRow(Row{"1", "alpha beta gamma delta"})
+	expected := `+---+--------+
+| 1 | alpha↵ |
+|   |  beta↵ |
+|   | gamma↵ |
+|   |  delta |
++---+--------+
+`
+	assert.Equal(t, expected, table.StringWithOptions(RenderOptions{MaxTTYWidth: 14}))
+	table.Column(1).Align = AlignCenter
+	table.AddRow(Row{"2", "a"})
+	expected = `+---+--------+
+| 1 | alpha↵ |
+|   | beta↵  |
+|   | gamma↵ |
+|   | delta  |
+| 2 |   a    |
++---+--------+
+`
+	assert.Equal(t, expected, table.StringWithOptions(RenderOptions{MaxTTYWidth: 14}))
+	table.Column(1).Align = AlignLeft
+	expected = `+---+--------+
+| 1 | alpha↵ |
+|   | beta ↵ |
+|   | gamma↵ |
+|   | delta  |
+| 2 | a      |
++---+--------+
+`
+	assert.Equal(t, expected, table.StringWithOptions(RenderOptions{MaxTTYWidth: 14}))
+}
+
+func TestColumnAlignmentTabWriter(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name", "Units", "Cost"}
+	table.Column(1).Align = AlignRight
+	table.Column(2).Align = AlignDecimal
+	table.AddRow(Row{"app1", "2", "1.5"})
+	table.AddRow(Row{"app2", "10", "10"})
+	expected := `NAME   UNITS   COST
+app1       2    1.5
+app2      10   10
+`
+	assert.Equal(t, expected, table.StringWithOptions(RenderOptions{UseTabWriter: true}))
+}
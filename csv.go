@@ -0,0 +1,41 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablecli
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// RenderCSV writes the table to w as comma separated values, with Headers
+// as the first record. Fields are quoted as described in RFC 4180, colors
+// are stripped, cells are never wrapped and Title, Caption and footer rows
+// are left out.
+func (t *Table) RenderCSV(w io.Writer) error {
+	return t.renderSeparatedValues(w, ',')
+}
+
+// RenderTSV writes the table to w as tab separated values, quoting fields
+// the same way RenderCSV does.
+func (t *Table) RenderTSV(w io.Writer) error {
+	return t.renderSeparatedValues(w, '\t')
+}
+
+func (t *Table) renderSeparatedValues(w io.Writer, comma rune) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	if len(t.Headers) > 0 {
+		cw.Write(t.exportHeaders())
+		cw.Flush()
+	}
+	for _, row := range t.rows {
+		if err := cw.Error(); err != nil {
+			return err
+		}
+		cw.Write(t.exportRow(row))
+		cw.Flush()
+	}
+	return cw.Error()
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablecli
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderCSV(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name", "Units"}
+	table.AddRow(Row{"app1", "2"})
+	table.AddRow(Row{"app2", "10"})
+	var buf strings.Builder
+	err := table.RenderCSV(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "Name,Units\napp1,2\napp2,10\n", buf.String())
+}
+
+func TestRenderCSVWithoutHeaders(t *testing.T) {
+	table := NewTable()
+	table.AddRow(Row{"One", "1"})
+	var buf strings.Builder
+	err := table.RenderCSV(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "One,1\n", buf.String())
+}
+
+func TestRenderCSVQuoting(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name", "Description"}
+	table.AddRow(Row{"a,b", `say "hi"`})
+	table.AddRow(Row{"multi", "line 1\nline 2"})
+	var buf strings.Builder
+	err := table.RenderCSV(&buf)
+	assert.NoError(t, err)
+	expected := `Name,Description
+"a,b","say ""hi"""
+multi,"line 1
+line 2"
+`
+	assert.Equal(t, expected, buf.String())
+	records, err := csv.NewReader(strings.NewReader(buf.String())).ReadAll()
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Name", "Description"},
+		{"a,b", `say "hi"`},
+		{"multi", "line 1\nline 2"},
+	}, records)
+}
+
+func TestRenderCSVStripsColorsAndNeverWraps(t *testing.T) {
+	table := NewTable()
+	table.Options = &RenderOptions{MaxTTYWidth: 10}
+	table.Headers = Row{withColor("Status"), "Description"}
+	table.AddRow(Row{withColor("started"), "a very long description that would be wrapped"})
+	var buf strings.Builder
+	err := table.RenderCSV(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "Status,Description\nstarted,a very long description that would be wrapped\n", buf.String())
+}
+
+func TestRenderTSV(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name", "Description"}
+	table.AddRow(Row{"app1", "with\ttab"})
+	table.AddRow(Row{"app2", "with, comma"})
+	var buf strings.Builder
+	err := table.RenderTSV(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "Name\tDescription\napp1\t\"with\ttab\"\napp2\twith, comma\n", buf.String())
+}
+
+func TestRenderCSVWriteError(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name"}
+	for i := 0; i < 10; i++ {
+		table.AddRow(Row{"One"})
+	}
+	w := &failWriter{failAfter: 2}
+	err := table.RenderCSV(w)
+	assert.EqualError(t, err, "write failed")
+	assert.Equal(t, 2, w.writes)
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablecli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// minExpandedValueWidth is the width the expanded display keeps for values
+// when the keys are too wide for the terminal, fitting the keys in the
+// rest of it.
+const minExpandedValueWidth = 5
+
+// renderExpanded writes each row as a block of "header | value" lines,
+// separated by "-[ RECORD n ]-" rules, like the psql expanded display.
+// Keys and values are wrapped to fit in ttyWidth.
+func (t *Table) renderExpanded(opts *RenderOptions, ttyWidth int, buf *lineWriter) {
+	if len(t.rows) == 0 {
+		return
+	}
+	keys := t.expandedKeys()
+	keyWidth := 0
+	for _, key := range keys {
+		keyWidth = max(keyWidth, cellWidth(key))
+	}
+	valueWidth := 0
+	body := t.bodyRows()
+	for _, row := range body {
+		for _, cell := range row {
+			valueWidth = max(valueWidth, cellWidth(cell))
+		}
+	}
+	// maxValueWidth is the width values are wrapped to, zero when they
+	// fit in the terminal.
+	var maxValueWidth int
+	if ttyWidth > 0 {
+		if limit := max(ttyWidth-3-min(valueWidth, minExpandedValueWidth), 2); keyWidth > limit {
+			keys, keyWidth = t.fitExpandedKeys(opts, keys, limit)
+		}
+		if available := max(ttyWidth-keyWidth-3, 2); valueWidth > available {
+			valueWidth = available
+			maxValueWidth = available
+		}
+	}
+	style := opts.borderStyle()
+	vbar, horiz, junction := opts.borderColor(style.Vertical), style.Middle.Horizontal, style.Middle.Cross
+	if t.Title != "" {
+		writeTextLine(buf, t.Title, t.TitleAlign, keyWidth+valueWidth+3)
+	}
+	for rowIdx, row := range body {
+		if buf.err != nil {
+			return
+		}
+		label := fmt.Sprintf("[ RECORD %d ]", rowIdx+1)
+		if rowIdx >= len(t.rows) {
+			label = fmt.Sprintf("[ FOOTER %d ]", rowIdx-len(t.rows)+1)
+		}
+		buf.WriteString(opts.borderColor(horiz))
+		buf.WriteString(label)
+		if left := keyWidth - displayWidth(label); left >= 0 {
+			buf.WriteString(opts.borderColor(strings.Repeat(horiz, left) + junction + strings.Repeat(horiz, valueWidth+1)))
+		} else if left := keyWidth + valueWidth + 2 - displayWidth(label); left > 0 {
+			buf.WriteString(opts.borderColor(strings.Repeat(horiz, left)))
+		}
+		buf.WriteString("\n")
+		for column, key := range keys {
+			var value string
+			if column < len(row) && row[column] != Spanned {
+				value = row[column]
+			}
+			if cellWidth(value) > valueWidth {
+				value = t.wrapper(opts, column).wrap(value, valueWidth)
+			}
+			value = clampCell(value, maxValueWidth, opts.MaxLinesPerCell)
+			keyLines, valueLines := strings.Split(key, "\n"), strings.Split(value, "\n")
+			for i := range max(len(keyLines), len(valueLines)) {
+				var line string
+				key = ""
+				if i < len(keyLines) {
+					key = keyLines[i]
+				}
+				if i < len(valueLines) {
+					line = valueLines[i]
+				}
+				buf.WriteString(key)
+				buf.WriteString(strings.Repeat(" ", keyWidth-displayWidth(key)+1))
+				buf.WriteString(vbar)
+				if line != "" {
+					buf.WriteString(" ")
+					buf.WriteString(line)
+				}
+				buf.WriteString("\n")
+			}
+		}
+	}
+	if t.Caption != "" {
+		writeTextLine(buf, t.Caption, t.CaptionAlign, keyWidth+valueWidth+3)
+	}
+}
+
+// expandedKeys returns the labels of each column in the expanded display,
+// using the column number for tables without headers. Spanned headers are
+// left empty.
+func (t *Table) expandedKeys() []string {
+	if len(t.Headers) > 0 {
+		return unspan(t.Headers)
+	}
+	var keys []string
+	for i := range t.rows[0] {
+		keys = append(keys, strconv.Itoa(i+1))
+	}
+	return keys
+}
+
+// fitExpandedKeys fits the keys of the expanded display to width like the
+// headers of narrow columns, returning them along with their actual width.
+func (t *Table) fitExpandedKeys(opts *RenderOptions, keys []string, width int) ([]string, int) {
+	fitted := make([]string, len(keys))
+	keyWidth := 0
+	for i, key := range keys {
+		if i < len(t.Headers) && key != "" {
+			key = t.fitHeader(opts, i, width)
+		}
+		fitted[i] = key
+		keyWidth = max(keyWidth, cellWidth(key))
+	}
+	return fitted, keyWidth
+}
+
+// cellWidth returns the width of the widest line in cell.
+func cellWidth(cell string) int {
+	width := 0
+	for _, line := range strings.Split(cell, "\n") {
+		width = max(width, displayWidth(line))
+	}
+	return width
+}
@@ -0,0 +1,176 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablecli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpanded(t *testing.T) {
+	table := NewTable()
+	table.Options = &RenderOptions{Expanded: true}
+	table.Headers = Row{"Name", "Units", "Description"}
+	table.AddRow(Row{"app1", "2", "my first app"})
+	table.AddRow(Row{"app2", "10", "another app"})
+	expected := `-[ RECORD 1 ]-------------
+Name        | app1
+Units       | 2
+Description | my first app
+-[ RECORD 2 ]-------------
+Name        | app2
+Units       | 10
+Description | another app
+`
+	assert.Equal(t, expected, table.String())
+}
+
+func TestExpandedJunction(t *testing.T) {
+	table := NewTable()
+	table.Options = &RenderOptions{Expanded: true}
+	table.Headers = Row{"Name", "Environments"}
+	table.AddRow(Row{"app1", ""})
+	expected := `-[ RECORD 1 ]+-----
+Name         | app1
+Environments |
+`
+	assert.Equal(t, expected, table.String())
+}
+
+func TestExpandedWithoutHeaders(t *testing.T) {
+	table := NewTable()
+	table.AddRow(Row{"One", "1"})
+	expected := `-[ RECORD 1 ]
+1 | One
+2 | 1
+`
+	assert.Equal(t, expected, table.StringWithOptions(RenderOptions{Expanded: true}))
+}
+
+func TestExpandedMultiline(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name", "Addresses"}
+	table.AddRow(Row{"app1", "10.0.0.1\n10.0.0.2"})
+	expected := `-[ RECORD 1 ]-------
+Name      | app1
+Addresses | 10.0.0.1
+          | 10.0.0.2
+`
+	assert.Equal(t, expected, table.StringWithOptions(RenderOptions{Expanded: true}))
+}
+
+func TestExpandedWrap(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name", "Description"}
+	table.AddRow(Row{"app1", "a very long description"})
+	expected := `-[ RECORD 1 ]--------
+Name        | app1
+Description | a very↵
+            | long  ↵
+            | descri↵
+            | ption
+`
+	assert.Equal(t, expected, table.StringWithOptions(RenderOptions{Expanded: true, MaxTTYWidth: 21}))
+	assert.Equal(t, Row{"app1", "a very long description"}, table.rows[0])
+}
+
+func TestExpandedUTF8BordersAndColor(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name"}
+	table.AddRow(Row{withColor("app1")})
+	opts := RenderOptions{
+		Expanded:        true,
+		UseUTF8Borders:  true,
+		BorderColorFunc: func(s string) string { return "[" + s + "]" },
+	}
+	expected := "[─][ RECORD 1 ]\n" +
+		"Name [│] " + withColor("app1") + "\n"
+	assert.Equal(t, expected, table.StringWithOptions(opts))
+}
+
+func TestExpandedUTF8Junction(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Environments"}
+	table.AddRow(Row{"x"})
+	expected := "─[ RECORD 1 ]┼──\nEnvironments │ x\n"
+	assert.Equal(t, expected, table.StringWithOptions(RenderOptions{Expanded: true, UseUTF8Borders: true}))
+}
+
+func TestExpandedEmpty(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name"}
+	assert.Equal(t, "", table.StringWithOptions(RenderOptions{Expanded: true}))
+}
+
+func TestAutoExpandNarrowTerminal(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name", "Platform", "Description"}
+	table.AddRow(Row{"app1", "python", "my app"})
+	expected := `-[ RECORD 1 ]-------
+Name        | app1
+Platform    | python
+Description | my app
+`
+	assert.Equal(t, expected, table.StringWithOptions(RenderOptions{AutoExpand: true, MaxTTYWidth: 20}))
+}
+
+func TestAutoExpandTableFits(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name", "Description"}
+	table.AddRow(Row{"app1", "my first app"})
+	expected := `+------+-------------+
+| Name | Description |
++------+-------------+
+| app1 | my first  ↵ |
+|      | app         |
++------+-------------+
+`
+	assert.Equal(t, expected, table.StringWithOptions(RenderOptions{AutoExpand: true, MaxTTYWidth: 22}))
+}
+
+func TestAutoExpandDisabled(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name", "Platform", "Description"}
+	table.AddRow(Row{"app1", "python", "my app"})
+	expected := `+------+----------+-------------+
+| Name | Platform | Description |
++------+----------+-------------+
+| app1 | python   | my app      |
++------+----------+-------------+
+`
+	assert.Equal(t, expected, table.StringWithOptions(RenderOptions{MaxTTYWidth: 20}))
+}
+
+func TestAutoExpandNoRows(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name", "Platform", "Description"}
+	expected := `+------+----------+-------------+
+| Name | Platform | Description |
++------+----------+-------------+
++------+----------+-------------+
+`
+	assert.Equal(t, expected, table.StringWithOptions(RenderOptions{AutoExpand: true, MaxTTYWidth: 20}))
+}
+
+func TestExpandedWrapKeys(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Application name", "Platform"}
+	table.AddRow(Row{"my-application", "python"})
+	expected := `-[ RECORD 1 ]-------
+Application | my-ap↵
+name        | plica↵
+            | tion
+Platform    | python
+`
+	assert.Equal(t, expected, table.StringWithOptions(RenderOptions{Expanded: true, MaxTTYWidth: 20}))
+	table.Column(0).ShortHeader = "App"
+	expected = `-[ RECORD 1 ]-------
+App      | my-appli↵
+         | cation
+Platform | python
+`
+	assert.Equal(t, expected, table.StringWithOptions(RenderOptions{Expanded: true, MaxTTYWidth: 20}))
+}
@@ -0,0 +1,56 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablecli
+
+import (
+	"strconv"
+	"strings"
+)
+
+// stripANSI removes the ANSI color sequences matched by ignoredPattern, used
+// by renderers that export data instead of drawing it on a terminal.
+func stripANSI(s string) string {
+	if strings.IndexByte(s, '\033') == -1 {
+		return s
+	}
+	return ignoredPattern.ReplaceAllString(s, "")
+}
+
+// exportHeaders returns the table headers without colors. Spanned headers
+// are named after the header spanning over them followed by their position
+// in the span, as in "Resources 2", so the keyed formats get one key per
+// column.
+func (t *Table) exportHeaders() []string {
+	headers := make([]string, len(t.Headers))
+	owner, pos := "", 1
+	for i, h := range unspan(t.Headers) {
+		if t.Headers[i] != Spanned || i == 0 {
+			owner, pos = stripANSI(h), 1
+			headers[i] = owner
+			continue
+		}
+		pos++
+		headers[i] = owner + " " + strconv.Itoa(pos)
+	}
+	return headers
+}
+
+// exportRow returns the cells of row without colors, leaving Spanned cells
+// empty. When the table has headers the row is padded or cut to have one
+// cell per header.
+func (t *Table) exportRow(row Row) []string {
+	row = unspan(row)
+	size := len(row)
+	if len(t.Headers) > 0 {
+		size = len(t.Headers)
+	}
+	cells := make([]string, size)
+	for i := range cells {
+		if i < len(row) {
+			cells[i] = stripANSI(row[i])
+		}
+	}
+	return cells
+}
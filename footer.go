@@ -0,0 +1,146 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablecli
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Aggregate is a value computed from the cells of a column and shown in a
+// footer row.
+type Aggregate int
+
+const (
+	AggregateNone Aggregate = iota
+	// AggregateSum adds the numbers in the column.
+	AggregateSum
+	// AggregateCount counts the cells that are not empty.
+	AggregateCount
+	AggregateMin
+	AggregateMax
+	// AggregateAvg is the mean of the numbers in the column.
+	AggregateAvg
+)
+
+// AddFooter adds a row drawn below the table rows, after a separator.
+// Footer rows are not sorted with the table rows and may have fewer cells
+// than the table has columns, e.g. a single "Total" label.
+func (t *Table) AddFooter(row Row) {
+	t.footers.add(row)
+}
+
+// footerRows returns the footer rows, padded with empty cells to the
+// number of columns, followed by the row with the aggregates of the
+// columns, when any column has one.
+func (t *Table) footerRows() rowSlice {
+	n := t.columnCount()
+	footers := make(rowSlice, 0, len(t.footers)+1)
+	for _, row := range t.footers {
+		if len(row) < n {
+			padded := make(Row, n)
+			copy(padded, row)
+			row = padded
+		}
+		footers = append(footers, row)
+	}
+	if aggregates := t.aggregateRow(); aggregates != nil {
+		footers = append(footers, aggregates)
+	}
+	if len(footers) == 0 {
+		return nil
+	}
+	return footers
+}
+
+// columnCount returns the number of columns of the table, given by the
+// headers or by the first row.
+func (t *Table) columnCount() int {
+	if len(t.Headers) == 0 && len(t.rows) > 0 {
+		return len(t.rows[0])
+	}
+	return len(t.Headers)
+}
+
+// bodyRows returns the table rows followed by the footer rows.
+func (t *Table) bodyRows() rowSlice {
+	footers := t.footerRows()
+	if len(footers) == 0 {
+		return t.rows
+	}
+	body := make(rowSlice, 0, len(t.rows)+len(footers))
+	body = append(body, t.rows...)
+	return append(body, footers...)
+}
+
+// aggregateRow returns a row with the Aggregate of each column, or the
+// FooterLabel of the columns without one. It returns nil when no column
+// has an Aggregate.
+func (t *Table) aggregateRow() Row {
+	n := t.columnCount()
+	var row Row
+	for i := range min(n, len(t.Columns)) {
+		spec := t.Columns[i]
+		if spec.Aggregate == AggregateNone {
+			continue
+		}
+		if row == nil {
+			row = make(Row, n)
+			for j := range min(n, len(t.Columns)) {
+				row[j] = t.Columns[j].FooterLabel
+			}
+		}
+		row[i] = t.aggregate(i, spec.Aggregate)
+	}
+	return row
+}
+
+// aggregate computes kind over the cells of column i. Colors are ignored
+// and cells that are not numbers are skipped, except by AggregateCount.
+func (t *Table) aggregate(i int, kind Aggregate) string {
+	var count, numbers, decimals int
+	var sum float64
+	minValue, maxValue := math.Inf(1), math.Inf(-1)
+	for _, row := range t.rows {
+		if i >= len(row) || row[i] == Spanned {
+			continue
+		}
+		cell := strings.TrimSpace(stripANSI(row[i]))
+		if cell != "" {
+			count++
+		}
+		value, err := strconv.ParseFloat(cell, 64)
+		if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+			continue
+		}
+		numbers++
+		sum += value
+		minValue = min(minValue, value)
+		maxValue = max(maxValue, value)
+		if _, fraction := splitDecimal(cell); len(fraction) > 1 {
+			decimals = max(decimals, len(fraction)-1)
+		}
+	}
+	if kind == AggregateCount {
+		return strconv.Itoa(count)
+	}
+	if numbers == 0 {
+		return ""
+	}
+	var value float64
+	switch kind {
+	case AggregateSum:
+		value = sum
+	case AggregateMin:
+		value = minValue
+	case AggregateMax:
+		value = maxValue
+	case AggregateAvg:
+		value = sum / float64(numbers)
+		decimals = max(decimals, 2)
+	}
+	return strconv.FormatFloat(value, 'f', decimals, 64)
+}
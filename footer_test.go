@@ -0,0 +1,185 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablecli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregate(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"App", "Units", "Memory"}
+	table.AddRow(Row{"web", "3", "512.5"})
+	table.AddRow(Row{"api", "10", "1024"})
+	table.AddRow(Row{"worker", "", "128.25"})
+	table.AddRow(Row{"cron", "n/a", "\x1b[31m64\x1b[0m"})
+	assert.Equal(t, "13", table.aggregate(1, AggregateSum))
+	assert.Equal(t, "3", table.aggregate(1, AggregateCount))
+	assert.Equal(t, "3", table.aggregate(1, AggregateMin))
+	assert.Equal(t, "10", table.aggregate(1, AggregateMax))
+	assert.Equal(t, "6.50", table.aggregate(1, AggregateAvg))
+	assert.Equal(t, "1728.75", table.aggregate(2, AggregateSum))
+	assert.Equal(t, "64.00", table.aggregate(2, AggregateMin))
+	assert.Equal(t, "432.19", table.aggregate(2, AggregateAvg))
+	assert.Equal(t, "", table.aggregate(0, AggregateSum))
+	assert.Equal(t, "4", table.aggregate(0, AggregateCount))
+}
+
+func TestAggregateRow(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"App", "Units", "Memory"}
+	table.AddRow(Row{"web", "3", "512.5"})
+	table.AddRow(Row{"api", "10", "1024"})
+	table.AddRow(Row{"worker", "", "128.25"})
+	assert.Nil(t, table.aggregateRow())
+	assert.Nil(t, table.footerRows())
+	table.Column(0).FooterLabel = "Total"
+	assert.Nil(t, table.aggregateRow())
+	table.Column(1).Aggregate = AggregateSum
+	assert.Equal(t, Row{"Total", "13", ""}, table.aggregateRow())
+	table.AddFooter(Row{"quota", "20", "2048"})
+	assert.Equal(t, rowSlice{{"quota", "20", "2048"}, {"Total", "13", ""}}, table.footerRows())
+	assert.Len(t, table.bodyRows(), 5)
+	assert.Equal(t, 3, table.Rows())
+}
+
+func TestStringFooters(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"App", "Units", "Memory"}
+	table.AddRow(Row{"web", "3", "512.5"})
+	table.AddRow(Row{"api", "10", "1024"})
+	table.AddRow(Row{"worker", "", "128.25"})
+	table.Column(0).FooterLabel = "Total"
+	table.Column(1).Aggregate = AggregateSum
+	table.Column(1).Align = AlignRight
+	table.Column(2).Aggregate = AggregateSum
+	table.Column(2).Align = AlignDecimal
+	table.AddFooter(Row{"Quota", "20", "2048"})
+	table.Sort()
+	expected := `+--------+-------+---------+
+| App    | Units |  Memory |
++--------+-------+---------+
+| api    |    10 | 1024    |
+| web    |     3 |  512.5  |
+| worker |       |  128.25 |
++--------+-------+---------+
+| Quota  |    20 | 2048    |
+| Total  |    13 | 1664.75 |
++--------+-------+---------+
+`
+	assert.Equal(t, expected, table.String())
+}
+
+func TestStringFootersLineSeparator(t *testing.T) {
+	table := NewTable()
+	table.Options = &RenderOptions{UseUTF8Borders: true}
+	table.LineSeparator = true
+	table.Headers = Row{"App", "Units"}
+	table.AddRow(Row{"web", "3"})
+	table.AddRow(Row{"api", "2"})
+	table.Column(1).Aggregate = AggregateCount
+	expected := `┌─────┬───────┐
+│ App │ Units │
+├─────┼───────┤
+│ web │ 3     │
+├─────┼───────┤
+│ api │ 2     │
+├─────┼───────┤
+│     │ 2     │
+└─────┴───────┘
+`
+	assert.Equal(t, expected, table.String())
+}
+
+func TestStringFootersHeaderLine(t *testing.T) {
+	style := BorderSingle
+	style.Header = BorderLine{"╞", "═", "╪", "╡"}
+	table := NewTable()
+	table.Options = &RenderOptions{BorderStyle: &style}
+	table.Headers = Row{"App"}
+	table.AddRow(Row{"web"})
+	table.AddFooter(Row{"end"})
+	expected := "┌─────┐\n│ App │\n╞═════╡\n│ web │\n╞═════╡\n│ end │\n└─────┘\n"
+	assert.Equal(t, expected, table.String())
+}
+
+func TestStringTabWriterFooters(t *testing.T) {
+	table := NewTable()
+	table.Options = &RenderOptions{UseTabWriter: true}
+	table.Headers = Row{"App", "Units", "Memory"}
+	table.AddRow(Row{"web", "3", "512.5"})
+	table.AddRow(Row{"api", "10", "1024"})
+	table.AddRow(Row{"worker", "", "128.25"})
+	table.Column(0).FooterLabel = "TOTAL"
+	table.Column(1).Aggregate = AggregateMax
+	expected := "APP      UNITS   MEMORY\n" +
+		"web      3       512.5\n" +
+		"api      10      1024\n" +
+		"worker           128.25\n" +
+		"TOTAL    10      \n"
+	assert.Equal(t, expected, table.String())
+}
+
+func TestExpandedFooters(t *testing.T) {
+	table := NewTable()
+	table.Options = &RenderOptions{Expanded: true}
+	table.Headers = Row{"App", "Units"}
+	table.AddRow(Row{"web", "3"})
+	table.AddFooter(Row{"all", "3"})
+	expected := `-[ RECORD 1 ]
+App   | web
+Units | 3
+-[ FOOTER 1 ]
+App   | all
+Units | 3
+`
+	assert.Equal(t, expected, table.String())
+}
+
+func TestStructuredFooters(t *testing.T) {
+	color.NoColor = false
+	defer func() { color.NoColor = true }()
+	table := NewTable()
+	table.Headers = Row{"App", "Units"}
+	table.AddRow(Row{"web", color.New(color.FgGreen).Sprint("3")})
+	table.AddRow(Row{"api", "2"})
+	table.Column(0).FooterLabel = "Total"
+	table.Column(1).Aggregate = AggregateSum
+
+	var b strings.Builder
+	assert.NoError(t, table.RenderHTML(&b))
+	assert.Contains(t, b.String(), "</tbody>\n<tfoot>\n<tr><td>Total</td><td>5</td></tr>\n</tfoot>\n</table>\n")
+
+	b.Reset()
+	assert.NoError(t, table.RenderMarkdown(&b))
+	assert.True(t, strings.HasSuffix(b.String(), "| Total | 5     |\n"))
+
+	b.Reset()
+	assert.NoError(t, table.RenderCSV(&b))
+	assert.Equal(t, "App,Units\nweb,3\napi,2\n", b.String())
+}
+
+func TestStringShortFooter(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"App", "Units"}
+	table.AddRow(Row{"web", "3"})
+	table.AddFooter(Row{"Total"})
+	assert.Equal(t, rowSlice{{"Total", ""}}, table.footerRows())
+	expected := `+-------+-------+
+| App   | Units |
++-------+-------+
+| web   | 3     |
++-------+-------+
+| Total |       |
++-------+-------+
+`
+	assert.Equal(t, expected, table.String())
+	assert.Equal(t, expected, table.StringWithOptions(RenderOptions{MaxTTYWidth: 20, ProportionalShrink: true}))
+	assert.Equal(t, "APP     UNITS\nweb     3\nTotal   \n", table.StringWithOptions(RenderOptions{UseTabWriter: true}))
+}
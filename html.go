@@ -0,0 +1,311 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablecli
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// RenderHTML writes the table to w as an HTML table. Cell text is escaped
+// and ANSI colors and styles are translated to <span style="..."> elements.
+// When a BorderColorFunc is set in the table Options or TableConfig, its
+// color is used as the CSS border color. Title is written as the table
+// <caption>, while footer rows and Caption are written in <tfoot>.
+func (t *Table) RenderHTML(w io.Writer) error {
+	opts := t.options()
+	borderColor := opts.borderCSSColor()
+	var style string
+	if borderColor != "" {
+		style = "border: 1px solid " + borderColor + "; border-collapse: collapse"
+	}
+	buf := &lineWriter{w: w}
+	buf.WriteString("<table" + htmlStyleAttr(style) + ">\n")
+	if t.Title != "" {
+		buf.WriteString("<caption" + htmlStyleAttr(textAlignCSS(t.TitleAlign)) + ">")
+		buf.WriteString(ansiToHTML(t.Title))
+		buf.WriteString("</caption>\n")
+	}
+	if len(t.Headers) > 0 {
+		buf.WriteString("<thead>\n")
+		t.writeHTMLRow(buf, t.Headers, "th", borderColor)
+		buf.WriteString("</thead>\n")
+	}
+	buf.WriteString("<tbody>\n")
+	for _, row := range t.rows {
+		if buf.err != nil {
+			break
+		}
+		if len(t.Headers) > 0 && len(row) > len(t.Headers) {
+			row = row[:len(t.Headers)]
+		}
+		t.writeHTMLRow(buf, row, "td", borderColor)
+	}
+	buf.WriteString("</tbody>\n")
+	footers := t.footerRows()
+	if len(footers) > 0 || t.Caption != "" {
+		buf.WriteString("<tfoot>\n")
+	}
+	for _, row := range footers {
+		if len(t.Headers) > 0 && len(row) > len(t.Headers) {
+			row = row[:len(t.Headers)]
+		}
+		t.writeHTMLRow(buf, row, "td", borderColor)
+	}
+	if t.Caption != "" {
+		var styles []string
+		if borderColor != "" {
+			styles = append(styles, "border: 1px solid "+borderColor)
+		}
+		if css := textAlignCSS(t.CaptionAlign); css != "" {
+			styles = append(styles, css)
+		}
+		colspan := len(t.Headers)
+		if colspan == 0 && len(t.rows) > 0 {
+			colspan = len(t.rows[0])
+		}
+		buf.WriteString(fmt.Sprintf("<tr><td colspan=\"%d\"%s>", colspan, htmlStyleAttr(strings.Join(styles, "; "))))
+		buf.WriteString(ansiToHTML(t.Caption))
+		buf.WriteString("</td></tr>\n")
+	}
+	if len(footers) > 0 || t.Caption != "" {
+		buf.WriteString("</tfoot>\n")
+	}
+	buf.WriteString("</table>\n")
+	return buf.flush()
+}
+
+// textAlignCSS returns the CSS declaration for align, if any.
+func textAlignCSS(align Alignment) string {
+	switch align {
+	case AlignLeft:
+		return "text-align: left"
+	case AlignRight, AlignDecimal:
+		return "text-align: right"
+	case AlignCenter:
+		return "text-align: center"
+	}
+	return ""
+}
+
+func (t *Table) writeHTMLRow(buf *lineWriter, row Row, tag, borderColor string) {
+	buf.WriteString("<tr>")
+	for i, cell := range row {
+		if cell == Spanned {
+			continue
+		}
+		var styles []string
+		if borderColor != "" {
+			styles = append(styles, "border: 1px solid "+borderColor)
+		}
+		align := t.columnSpec(i).Align
+		if tag == "th" {
+			align = t.columnSpec(i).headerAlign()
+		}
+		if css := textAlignCSS(align); css != "" {
+			styles = append(styles, css)
+		}
+		var colspan string
+		if n := cellSpan(row, i); n > 1 {
+			colspan = fmt.Sprintf(` colspan="%d"`, n)
+		}
+		buf.WriteString("<" + tag + colspan + htmlStyleAttr(strings.Join(styles, "; ")) + ">")
+		buf.WriteString(ansiToHTML(cell))
+		buf.WriteString("</" + tag + ">")
+	}
+	buf.WriteString("</tr>\n")
+}
+
+func htmlStyleAttr(style string) string {
+	if style == "" {
+		return ""
+	}
+	return ` style="` + style + `"`
+}
+
+// borderCSSColor returns the CSS color produced by BorderColorFunc, or an
+// empty string when there is no border color.
+func (o *RenderOptions) borderCSSColor() string {
+	if o.BorderColorFunc == nil {
+		return ""
+	}
+	var style sgrStyle
+	colored := o.BorderColorFunc("|")
+	for _, pos := range ignoredPattern.FindAllStringIndex(colored, -1) {
+		if pos[0] > strings.Index(colored, "|") {
+			break
+		}
+		style.apply(colored[pos[0]:pos[1]])
+	}
+	if style.fg == "" {
+		return o.borderCSS
+	}
+	return style.fg
+}
+
+// ansiToHTML escapes s and translates its ANSI SGR sequences into spans.
+// Line breaks are converted to <br>.
+func ansiToHTML(s string) string {
+	var b strings.Builder
+	var style sgrStyle
+	var openCSS string
+	writeText := func(text string) {
+		if text == "" {
+			return
+		}
+		if css := style.css(); css != openCSS {
+			if openCSS != "" {
+				b.WriteString("</span>")
+			}
+			if css != "" {
+				b.WriteString(`<span style="` + css + `">`)
+			}
+			openCSS = css
+		}
+		b.WriteString(strings.ReplaceAll(html.EscapeString(text), "\n", "<br>"))
+	}
+	last := 0
+	for _, pos := range ignoredPattern.FindAllStringIndex(s, -1) {
+		writeText(s[last:pos[0]])
+		style.apply(s[pos[0]:pos[1]])
+		last = pos[1]
+	}
+	writeText(s[last:])
+	if openCSS != "" {
+		b.WriteString("</span>")
+	}
+	return b.String()
+}
+
+// sgrStyle is the text style resulting from a series of ANSI SGR sequences.
+type sgrStyle struct {
+	fg, bg    string
+	bold      bool
+	faint     bool
+	italic    bool
+	underline bool
+	strike    bool
+}
+
+// apply updates the style with a single SGR sequence such as "\033[1;31m".
+func (s *sgrStyle) apply(seq string) {
+	var params []int
+	for _, p := range strings.Split(strings.TrimSuffix(strings.TrimPrefix(seq, "\033["), "m"), ";") {
+		n, _ := strconv.Atoi(p)
+		params = append(params, n)
+	}
+	for i := 0; i < len(params); i++ {
+		switch p := params[i]; {
+		case p == 0:
+			*s = sgrStyle{}
+		case p == 1:
+			s.bold = true
+		case p == 2:
+			s.faint = true
+		case p == 3:
+			s.italic = true
+		case p == 4:
+			s.underline = true
+		case p == 9:
+			s.strike = true
+		case p == 22:
+			s.bold, s.faint = false, false
+		case p == 23:
+			s.italic = false
+		case p == 24:
+			s.underline = false
+		case p == 29:
+			s.strike = false
+		case p >= 30 && p <= 37:
+			s.fg = ansiColor(p - 30)
+		case p >= 90 && p <= 97:
+			s.fg = ansiColor(p - 90 + 8)
+		case p == 39:
+			s.fg = ""
+		case p >= 40 && p <= 47:
+			s.bg = ansiColor(p - 40)
+		case p >= 100 && p <= 107:
+			s.bg = ansiColor(p - 100 + 8)
+		case p == 49:
+			s.bg = ""
+		case p == 38 || p == 48:
+			var color string
+			color, i = extendedColor(params, i)
+			if p == 38 {
+				s.fg = color
+			} else {
+				s.bg = color
+			}
+		}
+	}
+}
+
+// css returns the style as a CSS declaration list.
+func (s *sgrStyle) css() string {
+	var decls []string
+	if s.fg != "" {
+		decls = append(decls, "color: "+s.fg)
+	}
+	if s.bg != "" {
+		decls = append(decls, "background-color: "+s.bg)
+	}
+	if s.bold {
+		decls = append(decls, "font-weight: bold")
+	} else if s.faint {
+		decls = append(decls, "opacity: 0.7")
+	}
+	if s.italic {
+		decls = append(decls, "font-style: italic")
+	}
+	var decoration []string
+	if s.underline {
+		decoration = append(decoration, "underline")
+	}
+	if s.strike {
+		decoration = append(decoration, "line-through")
+	}
+	if len(decoration) > 0 {
+		decls = append(decls, "text-decoration: "+strings.Join(decoration, " "))
+	}
+	return strings.Join(decls, "; ")
+}
+
+// extendedColor parses the 256-color (38;5;n) and 24-bit (38;2;r;g;b)
+// forms starting at params[i], returning the color and the index of the
+// last parameter used.
+func extendedColor(params []int, i int) (string, int) {
+	if i+2 < len(params) && params[i+1] == 5 {
+		return ansiColor(params[i+2]), i + 2
+	}
+	if i+4 < len(params) && params[i+1] == 2 {
+		return fmt.Sprintf("#%02x%02x%02x", params[i+2]&0xff, params[i+3]&0xff, params[i+4]&0xff), i + 4
+	}
+	return "", len(params)
+}
+
+var ansiBasicColors = [16]string{
+	"#000000", "#cd0000", "#00cd00", "#cdcd00", "#0000ee", "#cd00cd", "#00cdcd", "#e5e5e5",
+	"#7f7f7f", "#ff0000", "#00ff00", "#ffff00", "#5c5cff", "#ff00ff", "#00ffff", "#ffffff",
+}
+
+// ansiColor returns the CSS color of an entry in the xterm 256 color
+// palette.
+func ansiColor(n int) string {
+	switch {
+	case n < 0 || n > 255:
+		return ""
+	case n < 16:
+		return ansiBasicColors[n]
+	case n < 232:
+		levels := [6]int{0, 95, 135, 175, 215, 255}
+		n -= 16
+		return fmt.Sprintf("#%02x%02x%02x", levels[n/36], levels[n/6%6], levels[n%6])
+	}
+	gray := 8 + (n-232)*10
+	return fmt.Sprintf("#%02x%02x%02x", gray, gray, gray)
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablecli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderHTML(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name", "Units"}
+	table.AddRow(Row{"app1", "2"})
+	table.AddRow(Row{"<b>app2</b> & co", "10"})
+	var buf strings.Builder
+	err := table.RenderHTML(&buf)
+	assert.NoError(t, err)
+	expected := `<table>
+<thead>
+<tr><th>Name</th><th>Units</th></tr>
+</thead>
+<tbody>
+<tr><td>app1</td><td>2</td></tr>
+<tr><td>&lt;b&gt;app2&lt;/b&gt; &amp; co</td><td>10</td></tr>
+</tbody>
+</table>
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestRenderHTMLWithoutHeaders(t *testing.T) {
+	table := NewTable()
+	table.AddRow(Row{"One", "a\nb"})
+	var buf strings.Builder
+	err := table.RenderHTML(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "<table>\n<tbody>\n<tr><td>One</td><td>a<br>b</td></tr>\n</tbody>\n</table>\n", buf.String())
+}
+
+func TestRenderHTMLAlignment(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name", "Units"}
+	table.Column(1).Align = AlignRight
+	table.AddRow(Row{"app1", "2"})
+	var buf strings.Builder
+	err := table.RenderHTML(&buf)
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), `<tr><td>app1</td><td style="text-align: right">2</td></tr>`)
+}
+
+func TestRenderHTMLBorderColor(t *testing.T) {
+	opts := RenderOptions{}
+	opts.SetBorderColorByString("#FF8800")
+	table := NewTable()
+	table.Options = &opts
+	table.Headers = Row{"Name"}
+	table.AddRow(Row{"app1"})
+	var buf strings.Builder
+	err := table.RenderHTML(&buf)
+	assert.NoError(t, err)
+	expected := `<table style="border: 1px solid #ff8800; border-collapse: collapse">
+<thead>
+<tr><th style="border: 1px solid #ff8800">Name</th></tr>
+</thead>
+<tbody>
+<tr><td style="border: 1px solid #ff8800">app1</td></tr>
+</tbody>
+</table>
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestRenderHTMLNamedBorderColor(t *testing.T) {
+	opts := RenderOptions{}
+	opts.SetBorderColorByString("red")
+	assert.Equal(t, "#cd0000", opts.borderCSSColor())
+	opts.SetBorderColorByString("hi-blue")
+	assert.Equal(t, "#5c5cff", opts.borderCSSColor())
+}
+
+func TestRenderHTMLBorderColorFunc(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = noColor }()
+	green := color.New(color.FgGreen)
+	opts := RenderOptions{BorderColorFunc: func(s string) string { return green.Sprint(s) }}
+	assert.Equal(t, "#00cd00", opts.borderCSSColor())
+	opts = RenderOptions{BorderColorFunc: func(s string) string { return "\x1b[38;2;1;2;3m" + s + "\x1b[0m" }}
+	assert.Equal(t, "#010203", opts.borderCSSColor())
+	opts = RenderOptions{BorderColorFunc: func(s string) string { return s }}
+	assert.Equal(t, "", opts.borderCSSColor())
+}
+
+func TestRenderHTMLWriteError(t *testing.T) {
+	table := NewTable()
+	for i := 0; i < 10; i++ {
+		table.AddRow(Row{"One"})
+	}
+	w := &failWriter{failAfter: 2}
+	err := table.RenderHTML(w)
+	assert.EqualError(t, err, "write failed")
+	assert.Equal(t, 2, w.writes)
+}
+
+func TestANSIToHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"plain", "a < b", "a &lt; b"},
+		{"basic red", "\x1b[31mfailed\x1b[0m", `<span style="color: #cd0000">failed</span>`},
+		{"bold red", "\x1b[1;31mfailed\x1b[0m", `<span style="color: #cd0000; font-weight: bold">failed</span>`},
+		{"high intensity", "\x1b[92mok\x1b[0m", `<span style="color: #00ff00">ok</span>`},
+		{"256 colors", "\x1b[38;5;196mx\x1b[0m", `<span style="color: #ff0000">x</span>`},
+		{"256 grayscale", "\x1b[38;5;244mx\x1b[0m", `<span style="color: #808080">x</span>`},
+		{"24-bit", "\x1b[38;2;255;128;0mx\x1b[0m", `<span style="color: #ff8000">x</span>`},
+		{"background", "\x1b[30;43mwarn\x1b[0m", `<span style="color: #000000; background-color: #cdcd00">warn</span>`},
+		{"styles", "\x1b[3;4;9mx\x1b[0m", `<span style="font-style: italic; text-decoration: underline line-through">x</span>`},
+		{"partial", "a \x1b[32mb\x1b[0m c", `a <span style="color: #00cd00">b</span> c`},
+		{"consecutive codes", "\x1b[1m\x1b[34mx\x1b[22my\x1b[0m", `<span style="color: #0000ee; font-weight: bold">x</span><span style="color: #0000ee">y</span>`},
+		{"unterminated", "\x1b[31mx", `<span style="color: #cd0000">x</span>`},
+		{"reset on start", "\x1b[0;31;10mx\x1b[0m", `<span style="color: #cd0000">x</span>`},
+		{"escaped text", "\x1b[31m<&>\x1b[0m", `<span style="color: #cd0000">&lt;&amp;&gt;</span>`},
+		{"newline", "\x1b[31ma\nb\x1b[0m", `<span style="color: #cd0000">a<br>b</span>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ansiToHTML(tt.input))
+		})
+	}
+}
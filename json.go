@@ -0,0 +1,87 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablecli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// MarshalJSON implements json.Marshaler. Rows are encoded as objects keyed
+// by Headers, or as arrays of strings when the table has no headers. Colors
+// are stripped, line breaks inside cells are kept and Title, Caption and
+// footer rows are left out, so the output only holds data.
+func (t *Table) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	buf := &lineWriter{w: &b}
+	t.writeJSON(buf, "", "")
+	err := buf.flush()
+	return b.Bytes(), err
+}
+
+// RenderJSON writes the table to w as a JSON array with one row per line,
+// using the same encoding as MarshalJSON.
+func (t *Table) RenderJSON(w io.Writer) error {
+	buf := &lineWriter{w: w}
+	t.writeJSON(buf, "\n", "  ")
+	return buf.flush()
+}
+
+func (t *Table) writeJSON(buf *lineWriter, newline, indent string) {
+	if len(t.rows) == 0 {
+		buf.WriteString("[]" + newline)
+		return
+	}
+	headers := t.exportHeaders()
+	buf.WriteString("[" + newline)
+	for i, row := range t.rows {
+		if buf.err != nil {
+			return
+		}
+		buf.WriteString(indent)
+		buf.WriteString(jsonRecord(headers, t.exportRow(row)))
+		if i < len(t.rows)-1 {
+			buf.WriteString(",")
+		}
+		buf.WriteString(newline)
+	}
+	buf.WriteString("]" + newline)
+}
+
+func jsonRecord(headers, cells []string) string {
+	var b strings.Builder
+	if len(headers) == 0 {
+		b.WriteString("[")
+		for i, cell := range cells {
+			if i > 0 {
+				b.WriteString(",")
+			}
+			b.WriteString(jsonString(cell))
+		}
+		b.WriteString("]")
+		return b.String()
+	}
+	b.WriteString("{")
+	for i, header := range headers {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString(jsonString(header))
+		b.WriteString(":")
+		b.WriteString(jsonString(cells[i]))
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+func jsonString(s string) string {
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	return strings.TrimSuffix(b.String(), "\n")
+}
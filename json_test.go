@@ -0,0 +1,125 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablecli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalJSONWithHeaders(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name", "Units"}
+	table.AddRow(Row{"app1", "2"})
+	table.AddRow(Row{"app2", "10"})
+	data, err := json.Marshal(table)
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"Name":"app1","Units":"2"},{"Name":"app2","Units":"10"}]`, string(data))
+}
+
+func TestMarshalJSONKeepsHeaderOrder(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Zeta", "Alpha"}
+	table.AddRow(Row{"z", "a"})
+	data, err := table.MarshalJSON()
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"Zeta":"z","Alpha":"a"}]`, string(data))
+}
+
+func TestMarshalJSONWithoutHeaders(t *testing.T) {
+	table := NewTable()
+	table.AddRow(Row{"One", "1"})
+	table.AddRow(Row{"Two", "2"})
+	data, err := json.Marshal(table)
+	assert.NoError(t, err)
+	assert.Equal(t, `[["One","1"],["Two","2"]]`, string(data))
+}
+
+func TestMarshalJSONEmpty(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name"}
+	data, err := json.Marshal(table)
+	assert.NoError(t, err)
+	assert.Equal(t, `[]`, string(data))
+}
+
+func TestMarshalJSONStripsColors(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{withColor("Status")}
+	table.AddRow(Row{withColor("started")})
+	table.AddRow(Row{"\x1b[38;2;255;128;0mstopped\x1b[0m"})
+	data, err := json.Marshal(table)
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"Status":"started"},{"Status":"stopped"}]`, string(data))
+}
+
+func TestMarshalJSONMultilineCells(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name", "Addresses"}
+	table.AddRow(Row{"app1", "10.0.0.1\n10.0.0.2 <primary> & more"})
+	table.Options = &RenderOptions{MaxTTYWidth: 10}
+	data, err := table.MarshalJSON()
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"Name":"app1","Addresses":"10.0.0.1\n10.0.0.2 <primary> & more"}]`, string(data))
+	var decoded []map[string]string
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "10.0.0.1\n10.0.0.2 <primary> & more", decoded[0]["Addresses"])
+}
+
+func TestMarshalJSONRowSizeMismatch(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"A", "B"}
+	table.AddRow(Row{"1"})
+	table.AddRow(Row{"1", "2", "3"})
+	data, err := json.Marshal(table)
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"A":"1","B":""},{"A":"1","B":"2"}]`, string(data))
+}
+
+func TestRenderJSON(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name", "Units"}
+	table.AddRow(Row{"app1", "2"})
+	table.AddRow(Row{"app2", "10"})
+	var buf strings.Builder
+	err := table.RenderJSON(&buf)
+	assert.NoError(t, err)
+	expected := `[
+  {"Name":"app1","Units":"2"},
+  {"Name":"app2","Units":"10"}
+]
+`
+	assert.Equal(t, expected, buf.String())
+	var decoded []map[string]string
+	assert.NoError(t, json.Unmarshal([]byte(buf.String()), &decoded))
+	assert.Len(t, decoded, 2)
+}
+
+func TestRenderJSONEmpty(t *testing.T) {
+	var buf strings.Builder
+	err := NewTable().RenderJSON(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "[]\n", buf.String())
+}
+
+func TestRenderJSONWriteError(t *testing.T) {
+	table := NewTable()
+	for i := 0; i < 10; i++ {
+		table.AddRow(Row{"One", "1"})
+	}
+	w := &failWriter{failAfter: 2}
+	err := table.RenderJSON(w)
+	assert.EqualError(t, err, "write failed")
+	assert.Equal(t, 2, w.writes)
+}
+
+func TestStripANSI(t *testing.T) {
+	assert.Equal(t, "plain", stripANSI("plain"))
+	assert.Equal(t, "red", stripANSI("\x1b[31mred\x1b[0m"))
+	assert.Equal(t, "bold red and rgb", stripANSI("\x1b[1;31mbold red\x1b[0m and \x1b[38;2;0;255;0mrgb\x1b[0m"))
+}
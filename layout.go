@@ -0,0 +1,208 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablecli
+
+import (
+	"slices"
+	"strings"
+)
+
+// defaultMinColumnWidth is the width below which ProportionalShrink does
+// not wrap a column unless its ColumnSpec sets a MinWidth.
+const defaultMinColumnWidth = 5
+
+// maxShrinkPasses bounds the number of times shrinkColumns wraps the table
+// again looking for column sizes that fit.
+const maxShrinkPasses = 32
+
+// layout wraps the table rows so the table fits in ttyWidth, returning the
+// wrapped rows and the resulting column sizes.
+func (t *Table) layout(opts *RenderOptions, ttyWidth int) (rowSlice, []int) {
+	if opts.ProportionalShrink {
+		return t.shrinkColumns(opts, ttyWidth)
+	}
+	return t.resizeLargestColumn(opts, ttyWidth)
+}
+
+// shrinkColumns spreads the width the table is missing over all columns,
+// in proportion to their natural widths and respecting their minimum
+// widths, wrapping again until the table fits or no column can shrink.
+func (t *Table) shrinkColumns(opts *RenderOptions, ttyWidth int) (rowSlice, []int) {
+	rows, sizes, specMins := t.constrainColumns(opts, ttyWidth)
+	if ttyWidth == 0 {
+		return rows, sizes
+	}
+	mins := make([]int, len(sizes))
+	for i, sz := range sizes {
+		minWidth := specMins[i]
+		if minWidth <= 0 {
+			minWidth = defaultMinColumnWidth
+		}
+		if i < len(t.Headers) {
+			minWidth = max(minWidth, t.headerMinWidth(i))
+		}
+		mins[i] = max(2, min(minWidth, sz))
+	}
+	for range maxShrinkPasses {
+		if opts.tableWidth(sizes) <= ttyWidth {
+			break
+		}
+		targets := limitWidths(shrinkWidths(sizes, mins, opts.tableWidth(sizes)-ttyWidth))
+		if slices.Equal(targets, sizes) {
+			break
+		}
+		newRows := t.wrapColumns(opts, t.bodyRows(), targets)
+		newSizes := padSizes(t.rowsSize(opts, newRows, targets), specMins)
+		// only narrower tables are taken, so passes cannot go back and
+		// forth between the same sizes.
+		if opts.tableWidth(newSizes) >= opts.tableWidth(sizes) {
+			break
+		}
+		rows, sizes = newRows, newSizes
+	}
+	return rows, sizes
+}
+
+// constrainColumns wraps the table rows according to the width limits of
+// the column specs, returning the wrapped rows, the resulting column sizes
+// and the minimum width of each column.
+func (t *Table) constrainColumns(opts *RenderOptions, ttyWidth int) (rowSlice, []int, []int) {
+	sizes := t.columnsSize(opts)
+	var contentWidth int
+	if ttyWidth > 0 {
+		contentWidth = ttyWidth - opts.tableWidth(make([]int, len(sizes)))
+	}
+	mins, maxs := t.widthLimits(len(sizes), contentWidth)
+	rows := t.bodyRows()
+	if slices.ContainsFunc(maxs, func(m int) bool { return m > 0 }) {
+		rows = t.wrapColumns(opts, rows, maxs)
+		sizes = t.rowsSize(opts, rows, maxs)
+	}
+	return rows, padSizes(sizes, mins), mins
+}
+
+// fitHeaders returns the headers fitted to the column widths with
+// fitHeader, or the headers themselves when widths is nil.
+func (t *Table) fitHeaders(opts *RenderOptions, widths []int) Row {
+	if t.Headers == nil || widths == nil {
+		return t.Headers
+	}
+	headers := make(Row, len(t.Headers))
+	for i, header := range t.Headers {
+		if i < len(widths) && header != Spanned {
+			header = t.fitHeader(opts, i, opts.spanLimit(widths, i, cellSpan(t.Headers, i)))
+		}
+		headers[i] = header
+	}
+	return headers
+}
+
+// fitHeader returns the header of column i when it fits in width, its
+// ShortHeader when that one fits, or the header wrapped to width. A zero
+// width means no limit.
+func (t *Table) fitHeader(opts *RenderOptions, i, width int) string {
+	header := t.Headers[i]
+	if width <= 0 || cellWidth(header) <= width {
+		return header
+	}
+	spec := t.columnSpec(i)
+	if spec.ShortHeader != "" && cellWidth(spec.ShortHeader) <= width {
+		return spec.ShortHeader
+	}
+	w := t.wrapper(opts, i)
+	w.marker = ""
+	return w.wrap(header, width)
+}
+
+// headerMinWidth returns the narrowest width the header of column i can be
+// fitted to without breaking words.
+func (t *Table) headerMinWidth(i int) int {
+	minWidth := 0
+	for _, word := range strings.Fields(t.Headers[i]) {
+		minWidth = max(minWidth, displayWidth(word))
+	}
+	if short := t.columnSpec(i).ShortHeader; short != "" {
+		minWidth = min(minWidth, cellWidth(short))
+	}
+	return minWidth
+}
+
+// limitWidths returns a copy of sizes to be used as width limits, where
+// empty columns are limited to one cell instead of having no limit.
+func limitWidths(sizes []int) []int {
+	widths := make([]int, len(sizes))
+	for i, sz := range sizes {
+		widths[i] = max(sz, 1)
+	}
+	return widths
+}
+
+// padSizes raises each column size to its minimum width.
+func padSizes(sizes, mins []int) []int {
+	for i := range sizes {
+		sizes[i] = max(sizes[i], mins[i])
+	}
+	return sizes
+}
+
+// shrinkWidths removes deficit from sizes, taking from each column in
+// proportion to its size and never going below mins.
+func shrinkWidths(sizes, mins []int, deficit int) []int {
+	targets := slices.Clone(sizes)
+	for deficit > 0 {
+		total := 0
+		for i := range targets {
+			if targets[i] > mins[i] {
+				total += sizes[i]
+			}
+		}
+		if total == 0 {
+			break
+		}
+		removed := 0
+		for i := range targets {
+			if targets[i] <= mins[i] {
+				continue
+			}
+			cut := max(1, deficit*sizes[i]/total)
+			cut = min(cut, targets[i]-mins[i], deficit-removed)
+			targets[i] -= cut
+			removed += cut
+			if removed == deficit {
+				break
+			}
+		}
+		deficit -= removed
+	}
+	return targets
+}
+
+// wrapColumns returns a copy of rows where every cell wider than the
+// width of its column in widths, or of the columns it spans, is wrapped with
+// the column wrapper. Columns with a zero width are left untouched.
+func (t *Table) wrapColumns(opts *RenderOptions, rows rowSlice, widths []int) rowSlice {
+	wrapped := make(rowSlice, len(rows))
+	for i, row := range rows {
+		newRow := make(Row, len(row))
+		for j, cell := range row {
+			if j < len(widths) {
+				if limit := opts.spanLimit(widths, j, cellSpan(row, j)); limit > 0 && cellWidth(cell) > limit {
+					cell = t.wrapper(opts, j).wrap(cell, limit)
+				}
+			}
+			newRow[j] = cell
+		}
+		wrapped[i] = newRow
+	}
+	return wrapped
+}
+
+func toRowSlice(rows [][]string) rowSlice {
+	result := make(rowSlice, len(rows))
+	for i, row := range rows {
+		result[i] = row
+	}
+	return result
+}
@@ -0,0 +1,163 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablecli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShrinkWidths(t *testing.T) {
+	assert.Equal(t, []int{20, 10}, shrinkWidths([]int{20, 10}, []int{5, 5}, 0))
+	assert.Equal(t, []int{14, 7}, shrinkWidths([]int{20, 10}, []int{5, 5}, 9))
+	assert.Equal(t, []int{5, 5}, shrinkWidths([]int{20, 10}, []int{5, 5}, 40))
+	assert.Equal(t, []int{3, 11, 6}, shrinkWidths([]int{3, 20, 10}, []int{3, 5, 5}, 13))
+	assert.Equal(t, []int{3, 3}, shrinkWidths([]int{3, 3}, []int{3, 3}, 5))
+}
+
+func TestShrinkColumns(t *testing.T) {
+	table := NewTable()
+	table.AddRow(Row{"1", "my-application-name", "a long description"})
+	rows, sizes := table.shrinkColumns(&RenderOptions{}, 30)
+	assert.Equal(t, []int{1, 9, 10}, sizes)
+	assert.Equal(t, Row{"1", "my-appli↵\ncation-n↵\name", "a long   ↵\ndescripti↵\non"}, rows[0])
+	assert.Equal(t, Row{"1", "my-application-name", "a long description"}, table.rows[0])
+}
+
+func TestShrinkColumnsFits(t *testing.T) {
+	table := NewTable()
+	table.AddRow(Row{"1", "app", "desc"})
+	rows, sizes := table.shrinkColumns(&RenderOptions{}, 30)
+	assert.Equal(t, []int{1, 3, 4}, sizes)
+	assert.Equal(t, table.rows, rows)
+	rows, sizes = table.shrinkColumns(&RenderOptions{}, 0)
+	assert.Equal(t, []int{1, 3, 4}, sizes)
+	assert.Equal(t, table.rows, rows)
+}
+
+func TestShrinkColumnsMinWidth(t *testing.T) {
+	table := NewTable()
+	table.Column(1).MinWidth = 19
+	table.AddRow(Row{"1", "my-application-name", "a long description"})
+	rows, sizes := table.shrinkColumns(&RenderOptions{}, 32)
+	assert.Equal(t, []int{1, 19, 5}, sizes)
+	assert.Equal(t, "my-application-name", rows[0][1])
+}
+
+func TestShrinkColumnsHeadersAreMinimum(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name", "Description"}
+	table.AddRow(Row{"my-application-name", "a long description"})
+	_, sizes := table.shrinkColumns(&RenderOptions{}, 20)
+	assert.Equal(t, []int{5, 11}, sizes)
+}
+
+func TestProportionalShrinkString(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name", "Description"}
+	table.AddRow(Row{"my-application-name", "a long description of it"})
+	expected := `+-----------+---------------+
+| Name      | Description   |
++-----------+---------------+
+| my-appli↵ | a long      ↵ |
+| cation-n↵ | description ↵ |
+| ame       | of it         |
++-----------+---------------+
+`
+	assert.Equal(t, expected, table.StringWithOptions(RenderOptions{ProportionalShrink: true, MaxTTYWidth: 29}))
+	assert.Equal(t, Row{"my-application-name", "a long description of it"}, table.rows[0])
+}
+
+func TestFitHeader(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name", "Number of units", "Description"}
+	table.Column(2).ShortHeader = "Desc"
+	opts := &RenderOptions{}
+	assert.Equal(t, "Name", table.fitHeader(opts, 0, 0))
+	assert.Equal(t, "Name", table.fitHeader(opts, 0, 4))
+	assert.Equal(t, "Number of\nunits", table.fitHeader(opts, 1, 10))
+	assert.Equal(t, "Desc", table.fitHeader(opts, 2, 6))
+	assert.Equal(t, "Des\ncri\npti\non", table.fitHeader(opts, 2, 3))
+	assert.Equal(t, Row{"Name", "Number of\nunits", "Desc"}, table.fitHeaders(opts, []int{4, 10, 6}))
+	assert.Equal(t, Row{"Name", "Number of units", "Description"}, table.Headers)
+}
+
+func TestHeaderMinWidth(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Number of units", "Description", "日本語"}
+	table.Column(1).ShortHeader = "Desc"
+	assert.Equal(t, 6, table.headerMinWidth(0))
+	assert.Equal(t, 4, table.headerMinWidth(1))
+	assert.Equal(t, 6, table.headerMinWidth(2))
+}
+
+func TestStringWrapsHeaders(t *testing.T) {
+	table := NewTable()
+	table.Options = &RenderOptions{MaxTTYWidth: 25}
+	table.Headers = Row{"Name", "Number of running units"}
+	table.AddRow(Row{"app1", "2"})
+	expected := `+------+---------------+
+| Name | Number of     |
+|      | running units |
++------+---------------+
+| app1 | 2             |
++------+---------------+
+`
+	assert.Equal(t, expected, table.String())
+}
+
+func TestStringShortHeader(t *testing.T) {
+	table := NewTable()
+	table.Options = &RenderOptions{MaxTTYWidth: 27, ProportionalShrink: true}
+	table.Headers = Row{"Name", "Description", "Units"}
+	table.Column(1).ShortHeader = "Desc"
+	table.AddRow(Row{"app1", "a long description", "2"})
+	expected := `+------+----------+-------+
+| Name | Desc     | Units |
++------+----------+-------+
+| app1 | a long ↵ |     2 |
+|      | descrip↵ |       |
+|      | tion     |       |
++------+----------+-------+
+`
+	table.Column(2).Align = AlignRight
+	assert.Equal(t, expected, table.String())
+}
+
+func TestStringTabWriterWrapsHeaders(t *testing.T) {
+	table := NewTable()
+	table.Options = &RenderOptions{UseTabWriter: true}
+	table.Headers = Row{"Name", "Number of units"}
+	table.Column(1).MaxWidth = 10
+	table.AddRow(Row{"app1", "2"})
+	expected := "NAME   NUMBER OF\n" +
+		"       UNITS\n" +
+		"app1   2\n"
+	assert.Equal(t, expected, table.String())
+}
+
+func TestProportionalShrinkEmptyShortHeader(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Cost 123.45", "Description of the app", "Long notes header", "Platform a.b.c.d"}
+	table.AddRow(Row{"a long description", "日本語のテキスト", "", "wwwwwwwww"})
+	table.Column(1).ShortHeader = "a"
+	table.Column(1).MinWidth = 1
+	table.Column(2).ShortHeader = "\n"
+	// the empty column used to get its full header back on every other
+	// pass, so shrinking never settled.
+	expected := " Cost    a      Platform\n" +
+		" 123.45         a.b.c.d\n" +
+		" a    ↵  日↵    wwwwwww↵\n" +
+		" long ↵  本↵    ww\n" +
+		" descr↵  語↵    \n" +
+		" iptio↵  の↵    \n" +
+		" n       テ↵    \n" +
+		"         キ↵    \n" +
+		"         ス↵    \n" +
+		"         ト     \n"
+	assert.Equal(t, expected, table.StringWithOptions(RenderOptions{ProportionalShrink: true, MaxTTYWidth: 7, BorderStyle: &BorderNone}))
+	assert.Equal(t, []int{2, 1, 1}, limitWidths([]int{2, 0, 1}))
+}
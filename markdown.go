@@ -0,0 +1,116 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablecli
+
+import (
+	"io"
+	"strings"
+)
+
+var markdownReplacer = strings.NewReplacer(
+	"\\", "\\\\",
+	"|", "\\|",
+	"*", "\\*",
+	"_", "\\_",
+	"`", "\\`",
+	"~", "\\~",
+	"[", "\\[",
+	"]", "\\]",
+	"<", "\\<",
+	">", "\\>",
+	"\r\n", "<br>",
+	"\n", "<br>",
+)
+
+// RenderMarkdown writes the table to w as a GitHub-flavored Markdown table.
+// Headers are used as the header row, colors are stripped, Markdown
+// characters are escaped, line breaks become <br> and the delimiter row
+// follows the Align setting of each column. Footer rows are written after
+// the other rows, Title in bold before the table and Caption in italics
+// after it.
+func (t *Table) RenderMarkdown(w io.Writer) error {
+	headers := t.exportHeaders()
+	for i, h := range headers {
+		headers[i] = markdownReplacer.Replace(h)
+	}
+	body := t.bodyRows()
+	rows := make([][]string, len(body))
+	numCols := len(headers)
+	for i, row := range body {
+		rows[i] = t.exportRow(row)
+		for j, cell := range rows[i] {
+			rows[i][j] = markdownReplacer.Replace(cell)
+		}
+		if len(rows[i]) > numCols {
+			numCols = len(rows[i])
+		}
+	}
+	if numCols == 0 {
+		return nil
+	}
+	widths := make([]int, numCols)
+	for i := range widths {
+		widths[i] = 3
+	}
+	for _, row := range append([][]string{headers}, rows...) {
+		for i, cell := range row {
+			if w := displayWidth(cell); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+	buf := &lineWriter{w: w}
+	if t.Title != "" {
+		buf.WriteString("**" + markdownText(t.Title) + "**\n\n")
+	}
+	writeMarkdownRow(buf, headers, widths)
+	for i, width := range widths {
+		buf.WriteString("| ")
+		buf.WriteString(markdownDelimiter(t.columnSpec(i).Align, width))
+		buf.WriteString(" ")
+	}
+	buf.WriteString("|\n")
+	for _, row := range rows {
+		if buf.err != nil {
+			break
+		}
+		writeMarkdownRow(buf, row, widths)
+	}
+	if t.Caption != "" {
+		buf.WriteString("\n*" + markdownText(t.Caption) + "*\n")
+	}
+	return buf.flush()
+}
+
+// markdownText returns text as a single line of escaped Markdown.
+func markdownText(text string) string {
+	return markdownReplacer.Replace(strings.Join(strings.Fields(stripANSI(text)), " "))
+}
+
+func writeMarkdownRow(buf *lineWriter, row []string, widths []int) {
+	for i, width := range widths {
+		var cell string
+		if i < len(row) {
+			cell = row[i]
+		}
+		buf.WriteString("| ")
+		buf.WriteString(cell)
+		buf.WriteString(strings.Repeat(" ", width-displayWidth(cell)))
+		buf.WriteString(" ")
+	}
+	buf.WriteString("|\n")
+}
+
+func markdownDelimiter(align Alignment, width int) string {
+	switch align {
+	case AlignLeft:
+		return ":" + strings.Repeat("-", width-1)
+	case AlignRight, AlignDecimal:
+		return strings.Repeat("-", width-1) + ":"
+	case AlignCenter:
+		return ":" + strings.Repeat("-", width-2) + ":"
+	}
+	return strings.Repeat("-", width)
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablecli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name", "Units"}
+	table.AddRow(Row{"app1", "2"})
+	table.AddRow(Row{"my-app", "10"})
+	var buf strings.Builder
+	err := table.RenderMarkdown(&buf)
+	assert.NoError(t, err)
+	expected := `| Name   | Units |
+| ------ | ----- |
+| app1   | 2     |
+| my-app | 10    |
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestRenderMarkdownAlignment(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name", "Status", "Units", "Id"}
+	table.Column(0).Align = AlignLeft
+	table.Column(1).Align = AlignCenter
+	table.Column(2).Align = AlignRight
+	table.AddRow(Row{"app1", "ok", "2", "1"})
+	var buf strings.Builder
+	err := table.RenderMarkdown(&buf)
+	assert.NoError(t, err)
+	expected := `| Name | Status | Units | Id  |
+| :--- | :----: | ----: | --- |
+| app1 | ok     | 2     | 1   |
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestRenderMarkdownEscaping(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name", "Value"}
+	table.AddRow(Row{"a|b", `*bold* _it_ [x] <b> \ ~s~ ` + "`c`"})
+	var buf strings.Builder
+	err := table.RenderMarkdown(&buf)
+	assert.NoError(t, err)
+	lines := strings.Split(buf.String(), "\n")
+	assert.Equal(t, `| a\|b | \*bold\* \_it\_ \[x\] \<b\> \\ \~s\~ \`+"`c\\`"+` |`, lines[2])
+}
+
+func TestRenderMarkdownNewlinesAndColors(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{withColor("Name"), "Addresses"}
+	table.AddRow(Row{withColor("app1"), "10.0.0.1\n10.0.0.2\r\n10.0.0.3"})
+	var buf strings.Builder
+	err := table.RenderMarkdown(&buf)
+	assert.NoError(t, err)
+	expected := "| Name | Addresses                        |\n" +
+		"| ---- | -------------------------------- |\n" +
+		"| app1 | 10.0.0.1<br>10.0.0.2<br>10.0.0.3 |\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestRenderMarkdownWithoutHeaders(t *testing.T) {
+	table := NewTable()
+	table.AddRow(Row{"One", "1"})
+	var buf strings.Builder
+	err := table.RenderMarkdown(&buf)
+	assert.NoError(t, err)
+	expected := `|     |     |
+| --- | --- |
+| One | 1   |
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestRenderMarkdownEmpty(t *testing.T) {
+	var buf strings.Builder
+	err := NewTable().RenderMarkdown(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "", buf.String())
+}
+
+func TestMarkdownDelimiter(t *testing.T) {
+	assert.Equal(t, "----", markdownDelimiter(AlignDefault, 4))
+	assert.Equal(t, ":---", markdownDelimiter(AlignLeft, 4))
+	assert.Equal(t, "---:", markdownDelimiter(AlignRight, 4))
+	assert.Equal(t, ":--:", markdownDelimiter(AlignCenter, 4))
+	assert.Equal(t, "---:", markdownDelimiter(AlignDecimal, 4))
+}
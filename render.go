@@ -5,19 +5,20 @@
 package tablecli
 
 import (
-	"bytes"
+	"io"
 	"os"
-	"reflect"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
-	"unicode"
-	"unicode/utf8"
 
 	"golang.org/x/term"
 )
 
-var TableConfig = struct {
+// RenderOptions holds the settings used to render a Table. A Table uses
+// its own Options when set and TableConfig otherwise, so tables with
+// different styles can be rendered concurrently.
+type RenderOptions struct {
 	BreakOnAny   bool
 	ForceWrap    bool
 	UseTabWriter bool
@@ -25,9 +26,55 @@ var TableConfig = struct {
 
 	TabWriterTruncate bool
 
+	// MaxLinesPerCell limits the number of lines a cell takes after
+	// wrapping, replacing the last ones with a "… (+N lines)" indicator,
+	// which follows the first line when it is 1. Zero means no limit.
+	MaxLinesPerCell int
+
+	// BreakChars lists the characters after which wrapped cells may be
+	// broken, DefaultBreakChars when empty. See also PathBreakChars and
+	// IdentifierBreakChars.
+	BreakChars string
+	// WrapMarker is added to every line of a wrapped cell that continues
+	// on the next one, DefaultWrapMarker when empty.
+	WrapMarker string
+	// NoWrapMarker wraps cells without any marker, which keeps the output
+	// friendly to copy and paste.
+	NoWrapMarker bool
+	// BalancedWrap breaks wrapped cells in lines of similar widths instead
+	// of filling each line as much as possible, which suits prose.
+	BalancedWrap bool
+
+	// Expanded renders each row as a block of "header | value" lines
+	// instead of a table, like psql \x.
+	Expanded bool
+
+	// AutoExpand switches to the Expanded display when the table does not
+	// fit in the terminal width even after wrapping.
+	AutoExpand bool
+
+	// ProportionalShrink wraps every column wider than its minimum width,
+	// in proportion to its natural width, instead of only the largest one.
+	ProportionalShrink bool
+
 	UseUTF8Borders  bool
 	BorderColorFunc func(string) string
-}{
+	// BorderStyle sets the glyphs used for borders, taking precedence over
+	// UseUTF8Borders. See ParseBorderStyle for the presets.
+	BorderStyle *BorderStyle
+	// NoOuterBorder leaves out the frame around the table.
+	NoOuterBorder bool
+	// NoColumnSeparators leaves out the vertical lines between columns.
+	NoColumnSeparators bool
+
+	// borderCSS is the CSS color set by SetBorderColorByString, used when
+	// BorderColorFunc output has no colors, e.g. when stdout is not a TTY.
+	borderCSS string
+}
+
+// TableConfig holds the default RenderOptions, used by tables that have no
+// Options of their own.
+var TableConfig = RenderOptions{
 	BreakOnAny:   false,
 	ForceWrap:    false,
 	UseTabWriter: false,
@@ -43,17 +90,34 @@ type separatorPosition int
 
 const (
 	sepTop separatorPosition = iota
+	sepHeader
 	sepMiddle
+	sepFooter
 	sepBottom
 )
 
-func borderColor(s string) string {
-	if TableConfig.BorderColorFunc != nil {
-		return TableConfig.BorderColorFunc(s)
+func (o *RenderOptions) borderColor(s string) string {
+	if o.BorderColorFunc != nil && s != "" {
+		return o.BorderColorFunc(s)
 	}
 	return s
 }
 
+func (o *RenderOptions) ttyWidth() int {
+	var ttyWidth int
+	terminalFd := int(os.Stdout.Fd())
+	if o.ForceWrap {
+		terminalFd = int(os.Stdin.Fd())
+	}
+	if term.IsTerminal(terminalFd) {
+		ttyWidth, _, _ = term.GetSize(terminalFd)
+	}
+	if o.MaxTTYWidth > 0 && (ttyWidth == 0 || ttyWidth > o.MaxTTYWidth) {
+		ttyWidth = o.MaxTTYWidth
+	}
+	return ttyWidth
+}
+
 // ignoredPatterns matches ANSI escape sequences produced by fatih/color and similar libraries.
 // Covers: single codes (\033[31m), multiple codes (\033[1;31m), 256-color (\033[38;5;196m),
 // 24-bit RGB (\033[38;2;255;128;0m), and reset sequences (\033[0m, \033[22m, etc.)
@@ -70,6 +134,21 @@ type Table struct {
 	Headers       Row
 	LineSeparator bool
 	rows          rowSlice
+	footers       rowSlice
+
+	// Title is drawn over the top border and Caption over the bottom one,
+	// or on lines of their own when those borders are not drawn. They may
+	// hold colors and are truncated to the table width.
+	Title        string
+	TitleAlign   Alignment
+	Caption      string
+	CaptionAlign Alignment
+
+	// Options overrides TableConfig for this table when not nil.
+	Options *RenderOptions
+
+	// Columns holds per-column settings, indexed like Headers.
+	Columns []ColumnSpec
 
 	TableWriterTruncate   bool
 	TableWriterPadding    int
@@ -95,119 +174,78 @@ func (t *Table) SortByColumn(columns ...int) {
 	sort.Sort(rowSliceByColumn{rowSlice: t.rows, columns: columns})
 }
 
-func (t *Table) addRows(rows rowSlice, sizes []int, buf *strings.Builder) {
-	vbar := borderColor("|")
-	if TableConfig.UseUTF8Borders {
-		vbar = borderColor("│")
+func (t *Table) options() RenderOptions {
+	if t.Options != nil {
+		return *t.Options
 	}
+	return TableConfig
+}
+
+// addRows writes rows, followed by separators when LineSeparator is set,
+// where the one after the last row is drawn at last, above next.
+func (t *Table) addRows(opts *RenderOptions, rows rowSlice, sizes []int, aligner *cellAligner, last separatorPosition, next Row, buf *lineWriter) {
 	for rowIdx, row := range rows {
-		extraRows := rowSlice{}
-		for column, field := range row {
-			parts := strings.Split(field, "\n")
-			field = parts[0]
-			for i := range parts[1:] {
-				var newRow Row
-				if len(extraRows) > i {
-					newRow = extraRows[i]
-				} else {
-					newRow = Row(make([]string, len(row)))
-					extraRows.add(newRow)
-				}
-				newRow[column] = parts[i+1]
-			}
-			buf.WriteString(vbar)
-			buf.WriteString(" ")
-			buf.WriteString(field)
-			buf.Write(bytes.Repeat([]byte(" "), sizes[column]+1-runeLen(field)))
+		if buf.err != nil {
+			return
 		}
-		buf.WriteString(vbar)
-		buf.WriteString("\n")
-		t.addRows(extraRows, sizes, buf)
-		ptr1 := reflect.ValueOf(rows).Pointer()
-		ptr2 := reflect.ValueOf(t.rows).Pointer()
-		if ptr1 == ptr2 && t.LineSeparator {
+		t.addRow(opts, row, sizes, aligner, buf)
+		if t.LineSeparator {
 			if rowIdx == len(rows)-1 {
-				t.separator(buf, sizes, sepBottom)
+				t.separator(buf, opts, sizes, last, row, next)
 			} else {
-				t.separator(buf, sizes, sepMiddle)
+				t.separator(buf, opts, sizes, sepMiddle, row, rows[rowIdx+1])
 			}
 		}
 	}
 }
 
-func splitJoinEvery(str string, n int) string {
-	breakOnAny := TableConfig.BreakOnAny
-	breakChars := []rune{' ', '.', ':', '='}
-	n -= 1
-	str = strings.TrimRightFunc(str, unicode.IsSpace)
-	lines := strings.Split(str, "\n")
-	var parts [][]rune
-	for _, line := range lines {
-		line = strings.TrimRightFunc(line, unicode.IsSpace)
-		lineRunes := []rune(line)
-		strLen := len(lineRunes)
-		var start, end int
-		for ; start < strLen; start = end {
-			end = start + n
-			for _, p := range ignoredPatterns {
-				pos := p.FindStringIndex(string(lineRunes[start:]))
-				if pos != nil && pos[0] < (end-start+1) {
-					end += pos[1] - pos[0]
-				}
-			}
-			if end > strLen {
-				end = strLen
-			}
-			oldEnd := end
-			skipSpace := false
-			breakableChar := false
-			if !breakOnAny && end < strLen {
-				for ; end > start; end-- {
-					for _, chr := range breakChars {
-						if chr == lineRunes[end] {
-							breakableChar = true
-							if chr == ' ' {
-								skipSpace = true
-							} else if end < oldEnd {
-								end++
-							}
-							break
-						}
-					}
-					if breakableChar {
-						break
-					}
-				}
-				if !breakableChar {
-					end = oldEnd
-				}
-			}
-			part := make([]rune, end-start)
-			copy(part, lineRunes[start:end])
-			if breakableChar {
-				padding := n - (end - start)
-				if padding > 0 {
-					part = append(part, []rune(strings.Repeat(" ", padding))...)
-				}
-				if skipSpace {
-					end++
-				}
-			}
-			if end < strLen {
-				part = append(part, rune('↵'))
+// addRow writes a single row, spreading cells with line breaks over as many
+// physical lines as needed. Cells followed by Spanned cells are drawn over
+// the columns they span.
+func (t *Table) addRow(opts *RenderOptions, row Row, sizes []int, aligner *cellAligner, buf io.StringWriter) {
+	style := opts.borderStyle()
+	extraRows := rowSlice{}
+	for column, field := range row {
+		if field == Spanned {
+			continue
+		}
+		parts := strings.Split(field, "\n")
+		field = parts[0]
+		for i := range parts[1:] {
+			var newRow Row
+			if len(extraRows) > i {
+				newRow = extraRows[i]
+			} else {
+				newRow = spannedLike(row)
+				extraRows.add(newRow)
 			}
-			parts = append(parts, part)
+			newRow[column] = parts[i+1]
+		}
+		span := cellSpan(row, column)
+		left, right := aligner.padding(column, field, opts.spanWidth(sizes, column, span))
+		if column == 0 {
+			buf.WriteString(opts.borderColor(style.Left))
+		} else {
+			buf.WriteString(opts.borderColor(style.Vertical))
 		}
+		buf.WriteString(strings.Repeat(" ", left+1))
+		buf.WriteString(field)
+		if column+span < len(row) || style.Right != "" {
+			buf.WriteString(strings.Repeat(" ", right+1))
+		}
+	}
+	buf.WriteString(opts.borderColor(style.Right))
+	buf.WriteString("\n")
+	for _, extraRow := range extraRows {
+		t.addRow(opts, extraRow, sizes, aligner, buf)
 	}
-	return redistributeColors(parts)
 }
 
-func redistributeColors(parts [][]rune) string {
+func redistributeColors(parts []string) string {
 	var result string
 	var lastStartColor, nextResetStr string
-	for _, part := range parts {
+	for _, partStr := range parts {
 		nextStartColor := lastStartColor
-		partStr := string(part)
 		startPos := ignoredPatterns[0].FindStringIndex(partStr)
 		resetPos := ignoredPatterns[1].FindStringIndex(partStr)
 		if startPos != nil {
@@ -231,28 +269,62 @@ func redistributeColors(parts [][]rune) string {
 	return strings.TrimRight(result, "\n")
 }
 
-func (t *Table) resizeLargestColumn(ttyWidth int) []int {
-	sizes := t.columnsSize()
+// resizeLargestColumn wraps the largest column so the table fits in
+// ttyWidth. The table rows are left untouched, the wrapped rows are returned
+// along with the resulting column sizes. Column specs are honored: the
+// largest column is only wrapped down to its minimum width.
+func (t *Table) resizeLargestColumn(opts *RenderOptions, ttyWidth int) (rowSlice, []int) {
+	rows, sizes, mins := t.constrainColumns(opts, ttyWidth)
 	if ttyWidth == 0 {
-		return sizes
+		return rows, sizes
 	}
-	fullSize := 0
+	fullSize := opts.tableWidth(sizes)
 	maxIdx, maxVal := -1, -1
 	for i, sz := range sizes {
-		fullSize += sz
-		if sz > maxVal {
+		if sz > maxVal && sz > mins[i] {
 			maxVal = sz
 			maxIdx = i
 		}
 	}
-	fullSize += len(sizes)*3 + 1
-	available := ttyWidth - (fullSize - maxVal)
-	if fullSize > ttyWidth && available > 1 {
-		for _, row := range t.rows {
-			row[maxIdx] = splitJoinEvery(row[maxIdx], available)
+	if maxIdx < 0 {
+		return rows, sizes
+	}
+	available := max(ttyWidth-(fullSize-maxVal), mins[maxIdx])
+	if fullSize <= ttyWidth || available <= 1 {
+		return rows, sizes
+	}
+	body := t.bodyRows()
+	widths := slices.Clone(sizes)
+	widths[maxIdx] = available
+	wrapped := make(rowSlice, len(rows))
+	for i, row := range rows {
+		newRow := make(Row, len(row))
+		copy(newRow, row)
+		// rewrap the cell covering the column, which may span others.
+		start := maxIdx
+		for start > 0 && start < len(row) && row[start] == Spanned {
+			start--
+		}
+		if start < len(row) && row[start] != Spanned {
+			newRow[start] = t.wrapper(opts, start).wrap(body[i][start], opts.spanWidth(widths, start, cellSpan(row, start)))
 		}
+		wrapped[i] = newRow
 	}
-	return t.columnsSize()
+	return wrapped, padSizes(t.rowsSize(opts, wrapped, limitWidths(widths)), mins)
+}
+
+// tableWidth returns the width of a boxed table with the given column
+// sizes, including borders and padding.
+func (o *RenderOptions) tableWidth(sizes []int) int {
+	style := o.borderStyle()
+	width := displayWidth(style.Left) + displayWidth(style.Right)
+	if len(sizes) > 1 {
+		width += (len(sizes) - 1) * displayWidth(style.Vertical)
+	}
+	for _, sz := range sizes {
+		width += sz + 2
+	}
+	return width
 }
 
 var tableWriterReplacer = strings.NewReplacer(
@@ -287,19 +359,35 @@ func expandRow(row Row) [][]string {
 	return result
 }
 
-func (t *Table) renderUsingTabWriterLike() string {
+func (t *Table) renderUsingTabWriterLike(opts *RenderOptions, ttyWidth int, buf *lineWriter) {
 	padding := strings.Repeat(" ", t.TableWriterPadding)
 
-	// Process rows and calculate column widths
+	// Calculate column widths
+	var numCols int
+	if len(t.Headers) > 0 {
+		numCols = len(t.Headers)
+	} else if len(t.rows) > 0 {
+		numCols = len(t.rows[0])
+	}
+	var contentWidth int
+	if ttyWidth > 0 {
+		contentWidth = ttyWidth - t.TableWriterPadding - 3*(numCols-1)
+	}
+	mins, maxs := t.widthLimits(numCols, contentWidth)
+	limited := slices.ContainsFunc(maxs, func(m int) bool { return m > 0 })
+
+	// Process rows
 	var processedRows [][]string
-	for _, row := range t.rows {
+	for _, row := range t.bodyRows() {
+		row = unspan(trimSpanned(row))
+		var lines [][]string
 		if t.TableWriterExpandRows {
-			processedRows = append(processedRows, expandRow(row)...)
+			lines = expandRow(row)
 		} else {
 			newRow := make([]string, len(row))
 			for j, col := range row {
 				if idx := strings.IndexAny(col, "\f\n\r"); idx >= 0 {
-					if TableConfig.TabWriterTruncate || t.TableWriterTruncate {
+					if opts.TabWriterTruncate || t.TableWriterTruncate {
 						col = col[:idx] + " ..."
 					} else {
 						col = tableWriterReplacer.Replace(col)
@@ -307,105 +395,183 @@ func (t *Table) renderUsingTabWriterLike() string {
 				}
 				newRow[j] = col
 			}
-			processedRows = append(processedRows, newRow)
+			lines = [][]string{newRow}
+		}
+		if limited {
+			var limitedLines [][]string
+			for _, line := range t.wrapColumns(opts, toRowSlice(lines), maxs) {
+				limitedLines = append(limitedLines, expandRow(line)...)
+			}
+			lines = limitedLines
+		}
+		if opts.MaxLinesPerCell > 0 {
+			lines = clampExpandedRow(lines, maxs, opts.MaxLinesPerCell)
 		}
+		processedRows = append(processedRows, lines...)
 	}
 
-	// Calculate column widths
-	var numCols int
+	var headerLines [][]string
 	if len(t.Headers) > 0 {
-		numCols = len(t.Headers)
-	} else if len(processedRows) > 0 {
-		numCols = len(processedRows[0])
-	}
-	widths := make([]int, numCols)
-	for i, h := range t.Headers {
-		if w := runeLen(h); w > widths[i] {
-			widths[i] = w
+		headers := unspan(trimSpanned(t.fitHeaders(opts, maxs)))
+		for i, h := range headers {
+			headers[i] = strings.ToUpper(h)
 		}
+		headerLines = expandRow(headers)
 	}
-	for _, row := range processedRows {
+	widths := slices.Clone(mins)
+	for _, row := range slices.Concat(headerLines, processedRows) {
 		for i, col := range row {
 			if i < numCols {
-				if w := runeLen(col); w > widths[i] {
+				if w := displayWidth(col); w > widths[i] {
 					widths[i] = w
 				}
 			}
 		}
 	}
+	for i, dec := range t.decimalWidths(toRowSlice(processedRows), numCols) {
+		widths[i] = max(widths[i], dec.width())
+	}
 
 	// Build output
-	var buf strings.Builder
-	if len(t.Headers) > 0 {
+	textWidth := 3 * max(numCols-1, 0)
+	for _, w := range widths {
+		textWidth += w
+	}
+	if t.Title != "" {
 		buf.WriteString(padding)
-		for i, h := range t.Headers {
-			if i > 0 {
-				buf.WriteString("   ")
-			}
-			buf.WriteString(strings.ToUpper(h))
-			if i < numCols-1 {
-				buf.WriteString(strings.Repeat(" ", widths[i]-runeLen(h)))
-			}
-		}
-		buf.WriteString("\n")
+		writeTextLine(buf, t.Title, t.TitleAlign, textWidth)
+	}
+	headerAligner := t.newAligner(nil, numCols, true)
+	for _, line := range headerLines {
+		writeTabWriterLine(buf, padding, line, widths, headerAligner)
 	}
+	aligner := t.newAligner(toRowSlice(processedRows), numCols, false)
 	for _, row := range processedRows {
+		if buf.err != nil {
+			return
+		}
+		writeTabWriterLine(buf, padding, row, widths, aligner)
+	}
+	if t.Caption != "" {
 		buf.WriteString(padding)
-		for i, col := range row {
-			if i > 0 {
-				buf.WriteString("   ")
-			}
+		writeTextLine(buf, t.Caption, t.CaptionAlign, textWidth)
+	}
+}
+
+func writeTabWriterLine(buf *lineWriter, padding string, row []string, widths []int, aligner *cellAligner) {
+	buf.WriteString(padding)
+	for i, col := range row {
+		if i > 0 {
+			buf.WriteString("   ")
+		}
+		if i >= len(widths) {
 			buf.WriteString(col)
-			if i < numCols-1 {
-				buf.WriteString(strings.Repeat(" ", widths[i]-runeLen(col)))
-			}
+			continue
+		}
+		left, right := aligner.padding(i, col, widths[i])
+		buf.WriteString(strings.Repeat(" ", left))
+		buf.WriteString(col)
+		if i < min(len(row), len(widths))-1 {
+			buf.WriteString(strings.Repeat(" ", right))
 		}
-		buf.WriteString("\n")
 	}
-	return buf.String()
+	buf.WriteString("\n")
 }
 
 func (t *Table) String() string {
-	if TableConfig.UseTabWriter {
-		return t.renderUsingTabWriterLike()
+	return t.StringWithOptions(t.options())
+}
+
+// StringWithOptions renders the table using opts instead of the table
+// Options or TableConfig.
+func (t *Table) StringWithOptions(opts RenderOptions) string {
+	var buf strings.Builder
+	t.RenderWithOptions(&buf, opts)
+	return buf.String()
+}
+
+// Render writes the table to w line by line, returning the first write
+// error. It uses the table Options or TableConfig.
+func (t *Table) Render(w io.Writer) error {
+	return t.RenderWithOptions(w, t.options())
+}
+
+// RenderWithOptions writes the table to w line by line using opts instead
+// of the table Options or TableConfig.
+func (t *Table) RenderWithOptions(w io.Writer, opts RenderOptions) error {
+	_, err := t.render(w, &opts)
+	return err
+}
+
+// WriteTo implements io.WriterTo, rendering the table to w.
+func (t *Table) WriteTo(w io.Writer) (int64, error) {
+	opts := t.options()
+	return t.render(w, &opts)
+}
+
+func (t *Table) render(w io.Writer, opts *RenderOptions) (int64, error) {
+	buf := &lineWriter{w: w}
+	if opts.UseTabWriter {
+		t.renderUsingTabWriterLike(opts, opts.ttyWidth(), buf)
+		return buf.n, buf.flush()
 	}
-	if t.Headers == nil && len(t.rows) < 1 {
-		return ""
+	ttyWidth := opts.ttyWidth()
+	if opts.Expanded {
+		t.renderExpanded(opts, ttyWidth, buf)
+		return buf.n, buf.flush()
 	}
-	var ttyWidth int
-	terminalFd := int(os.Stdout.Fd())
-	if TableConfig.ForceWrap {
-		terminalFd = int(os.Stdin.Fd())
+	if t.Headers == nil && len(t.rows) < 1 {
+		return 0, nil
 	}
-	if term.IsTerminal(terminalFd) {
-		ttyWidth, _, _ = term.GetSize(terminalFd)
+	body, sizes := t.layout(opts, ttyWidth)
+	if opts.AutoExpand && ttyWidth > 0 && len(t.rows) > 0 && opts.tableWidth(sizes) > ttyWidth {
+		t.renderExpanded(opts, ttyWidth, buf)
+		return buf.n, buf.flush()
 	}
-	if TableConfig.MaxTTYWidth > 0 && (ttyWidth == 0 || ttyWidth > TableConfig.MaxTTYWidth) {
-		ttyWidth = TableConfig.MaxTTYWidth
+	if opts.MaxLinesPerCell > 0 {
+		var widths []int
+		if ttyWidth > 0 {
+			widths = sizes
+		}
+		body = clampRows(body, widths, opts.MaxLinesPerCell)
+		for i, sz := range t.rowsSize(opts, body, limitWidths(sizes)) {
+			sizes[i] = max(sizes[i], sz)
+		}
 	}
-	sizes := t.resizeLargestColumn(ttyWidth)
-	buf := &strings.Builder{}
-	t.separator(buf, sizes, sepTop)
+	// above tracks the row drawn last, so separators can tell which
+	// junctions are hidden under spanned cells.
+	var above Row
+	rows, footers := body[:len(t.rows)], body[len(t.rows):]
 	if t.Headers != nil {
-		vbar := borderColor("|")
-		if TableConfig.UseUTF8Borders {
-			vbar = borderColor("│")
-		}
-		for column, header := range t.Headers {
-			buf.WriteString(vbar)
-			buf.WriteString(" ")
-			buf.WriteString(header)
-			buf.Write(bytes.Repeat([]byte(" "), sizes[column]+1-len(header)))
+		headers := t.fitHeaders(opts, limitWidths(sizes))
+		t.separator(buf, opts, sizes, sepTop, nil, headers)
+		t.addRow(opts, headers, sizes, t.newAligner(nil, len(sizes), true), buf)
+		t.separator(buf, opts, sizes, sepHeader, headers, firstRow(body))
+		above = headers
+	} else {
+		t.separator(buf, opts, sizes, sepTop, nil, firstRow(body))
+	}
+	aligner := t.newAligner(body, len(sizes), false)
+	last := sepBottom
+	if len(footers) > 0 {
+		last = sepFooter
+	}
+	t.addRows(opts, rows, sizes, aligner, last, firstRow(footers), buf)
+	if len(rows) > 0 {
+		above = rows[len(rows)-1]
+	}
+	if len(footers) > 0 {
+		if !t.LineSeparator || len(rows) == 0 {
+			t.separator(buf, opts, sizes, sepFooter, above, footers[0])
 		}
-		buf.WriteString(vbar)
-		buf.WriteString("\n")
-		t.separator(buf, sizes, sepMiddle)
+		t.addRows(opts, footers, sizes, aligner, sepBottom, nil, buf)
+		above = footers[len(footers)-1]
 	}
-	t.addRows(t.rows, sizes, buf)
 	if !t.LineSeparator {
-		t.separator(buf, sizes, sepBottom)
+		t.separator(buf, opts, sizes, sepBottom, above, nil)
 	}
-	return buf.String()
+	err := buf.flush()
+	return buf.n, err
 }
 
 func (t *Table) Bytes() []byte {
@@ -420,45 +586,38 @@ func (t *Table) Rows() int {
 	return t.rows.Len()
 }
 
-func runeLen(s string) int {
-	if strings.IndexByte(s, '\033') == -1 {
-		return utf8.RuneCountInString(s)
-	}
-	positions := ignoredPattern.FindAllStringIndex(s, -1)
-	if len(positions) == 0 {
-		return utf8.RuneCountInString(s)
-	}
-	var count int
-	start := 0
-	for _, pos := range positions {
-		count += utf8.RuneCountInString(s[start:pos[0]])
-		start = pos[1]
-	}
-	return count
+func (t *Table) columnsSize(opts *RenderOptions) []int {
+	return t.rowsSize(opts, t.bodyRows(), nil)
 }
 
-func (t *Table) columnsSize() []int {
+func columnsSize(headers Row, rows rowSlice) []int {
 	var columns int
-	if t.Headers != nil {
-		columns = len(t.Headers)
+	if headers != nil {
+		columns = len(headers)
 	} else {
-		columns = len(t.rows[0])
+		columns = len(rows[0])
 	}
 	sizes := make([]int, columns)
-	for _, row := range t.rows {
+	for _, row := range rows {
 		for i := 0; i < columns; i++ {
+			if cellSpan(row, i) > 1 {
+				continue
+			}
 			rowParts := strings.Split(row[i], "\n")
 			for _, part := range rowParts {
-				partLen := runeLen(part)
+				partLen := displayWidth(part)
 				if partLen > sizes[i] {
 					sizes[i] = partLen
 				}
 			}
 		}
 	}
-	if t.Headers != nil {
-		for i, header := range t.Headers {
-			headerLen := runeLen(header)
+	if headers != nil {
+		for i, header := range headers {
+			if cellSpan(headers, i) > 1 {
+				continue
+			}
+			headerLen := cellWidth(header)
 			if headerLen > sizes[i] {
 				sizes[i] = headerLen
 			}
@@ -467,32 +626,65 @@ func (t *Table) columnsSize() []int {
 	return sizes
 }
 
-func (t *Table) separator(buf *strings.Builder, sizes []int, pos separatorPosition) {
-	left, mid, right, horiz := "+", "+", "+", "-"
-	if TableConfig.UseUTF8Borders {
-		switch pos {
-		case sepTop:
-			left, mid, right, horiz = "┌", "┬", "┐", "─"
-		case sepMiddle:
-			left, mid, right, horiz = "├", "┼", "┤", "─"
-		case sepBottom:
-			left, mid, right, horiz = "└", "┴", "┘", "─"
+func (t *Table) separator(buf io.StringWriter, opts *RenderOptions, sizes []int, pos separatorPosition, above, below Row) {
+	line := opts.borderStyle().line(pos)
+	text, align := t.frameText(pos)
+	if line.Horizontal == "" {
+		if text != "" {
+			writeTextLine(buf, text, align, opts.tableWidth(sizes))
+		}
+		return
+	}
+	crosses := junctions(line, pos, len(sizes), above, below)
+	if text != "" {
+		if framed, ok := framedLine(opts, line, sizes, crosses, text, align); ok {
+			buf.WriteString(framed)
+			buf.WriteString("\n")
+			return
 		}
 	}
-	colorLeft := borderColor(left)
-	colorMid := borderColor(mid)
-	colorRight := borderColor(right)
-	buf.WriteString(colorLeft)
+	buf.WriteString(opts.borderColor(line.Left))
 	for i, sz := range sizes {
 		if i > 0 {
-			buf.WriteString(colorMid)
+			buf.WriteString(opts.borderColor(crosses[i-1]))
 		}
-		buf.WriteString(borderColor(strings.Repeat(horiz, sz+2)))
+		buf.WriteString(opts.borderColor(strings.Repeat(line.Horizontal, sz+2)))
 	}
-	buf.WriteString(colorRight)
+	buf.WriteString(opts.borderColor(line.Right))
 	buf.WriteString("\n")
 }
 
+// lineWriter buffers output until the end of a line and then writes it to
+// w, so tables are streamed line by line. The first write error is kept and
+// every write after it is discarded.
+type lineWriter struct {
+	w   io.Writer
+	buf []byte
+	n   int64
+	err error
+}
+
+func (lw *lineWriter) WriteString(s string) (int, error) {
+	if lw.err != nil {
+		return 0, lw.err
+	}
+	lw.buf = append(lw.buf, s...)
+	if strings.HasSuffix(s, "\n") {
+		lw.flush()
+	}
+	return len(s), lw.err
+}
+
+func (lw *lineWriter) flush() error {
+	if lw.err == nil && len(lw.buf) > 0 {
+		n, err := lw.w.Write(lw.buf)
+		lw.n += int64(n)
+		lw.err = err
+		lw.buf = lw.buf[:0]
+	}
+	return lw.err
+}
+
 type rowSlice []Row
 
 type rowSliceByColumn struct {
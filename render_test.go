@@ -5,9 +5,13 @@
 package tablecli
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"sort"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -68,14 +72,14 @@ func TestColumnsSize(t *testing.T) {
 	table.AddRow(Row{"One", "1"})
 	table.AddRow(Row{"Two", "2"})
 	table.AddRow(Row{"Three", "3"})
-	assert.Equal(t, []int{5, 1}, table.columnsSize())
+	assert.Equal(t, []int{5, 1}, table.columnsSize(&TableConfig))
 }
 
 func TestSeparator(t *testing.T) {
 	table := NewTable()
 	expected := "+-------+---+\n"
 	buf := &strings.Builder{}
-	table.separator(buf, []int{5, 1}, sepTop)
+	table.separator(buf, &TableConfig, []int{5, 1}, sepTop, nil, nil)
 	assert.Equal(t, expected, buf.String())
 }
 
@@ -245,71 +249,73 @@ func TestResizeLargestColumn(t *testing.T) {
 	tb := NewTable()
 	tb.AddRow(Row{"1", "abcdefghijk"})
 	tb.AddRow(Row{"2", "1234567890"})
-	sizes := tb.resizeLargestColumn(11)
+	rows, sizes := tb.resizeLargestColumn(&TableConfig, 11)
 	assert.Equal(t, []int{1, 3}, sizes)
 	assert.Equal(t, Row{"1", `ab↵
 cd↵
 ef↵
 gh↵
 ij↵
-k`}, tb.rows[0])
+k`}, rows[0])
 	assert.Equal(t, Row{"2", `12↵
 34↵
 56↵
 78↵
-90`}, tb.rows[1])
+90`}, rows[1])
+	assert.Equal(t, Row{"1", "abcdefghijk"}, tb.rows[0])
+	assert.Equal(t, Row{"2", "1234567890"}, tb.rows[1])
 }
 
 func TestResizeLargestColumnOnMiddle(t *testing.T) {
 	tb := NewTable()
 	tb.AddRow(Row{"1", "abcdefghijk", "x"})
 	tb.AddRow(Row{"2", "1234567890", "y"})
-	sizes := tb.resizeLargestColumn(15)
+	rows, sizes := tb.resizeLargestColumn(&TableConfig, 15)
 	assert.Equal(t, []int{1, 3, 1}, sizes)
 	assert.Equal(t, Row{"1", `ab↵
 cd↵
 ef↵
 gh↵
 ij↵
-k`, "x"}, tb.rows[0])
+k`, "x"}, rows[0])
 	assert.Equal(t, Row{"2", `12↵
 34↵
 56↵
 78↵
-90`, "y"}, tb.rows[1])
+90`, "y"}, rows[1])
 }
 
 func TestResizeLargestColumnNoTTYSize(t *testing.T) {
 	tb := NewTable()
 	tb.AddRow(Row{"1", "abcdefghijk"})
 	tb.AddRow(Row{"2", "1234567890"})
-	sizes := tb.resizeLargestColumn(0)
+	rows, sizes := tb.resizeLargestColumn(&TableConfig, 0)
 	assert.Equal(t, []int{1, 11}, sizes)
-	assert.Equal(t, Row{"1", "abcdefghijk"}, tb.rows[0])
-	assert.Equal(t, Row{"2", "1234567890"}, tb.rows[1])
+	assert.Equal(t, Row{"1", "abcdefghijk"}, rows[0])
+	assert.Equal(t, Row{"2", "1234567890"}, rows[1])
 }
 
 func TestResizeLargestColumnNotEnoughSpace(t *testing.T) {
 	tb := NewTable()
 	tb.AddRow(Row{"1", "abcdefghijk"})
 	tb.AddRow(Row{"2", "1234567890"})
-	sizes := tb.resizeLargestColumn(9)
+	rows, sizes := tb.resizeLargestColumn(&TableConfig, 9)
 	assert.Equal(t, []int{1, 11}, sizes)
-	assert.Equal(t, Row{"1", "abcdefghijk"}, tb.rows[0])
-	assert.Equal(t, Row{"2", "1234567890"}, tb.rows[1])
+	assert.Equal(t, Row{"1", "abcdefghijk"}, rows[0])
+	assert.Equal(t, Row{"2", "1234567890"}, rows[1])
 }
 
 func TestResizeLargestColumnWithLineBreaks(t *testing.T) {
 	tb := NewTable()
 	tb.AddRow(Row{"1", "abcde\nfgh\ni\njklm"})
-	sizes := tb.resizeLargestColumn(12)
+	rows, sizes := tb.resizeLargestColumn(&TableConfig, 12)
 	assert.Equal(t, []int{1, 4}, sizes)
 	assert.Equal(t, Row{"1", `abc↵
 de
 fgh
 i
 jkl↵
-m`}, tb.rows[0])
+m`}, rows[0])
 }
 
 func withColor(s string) string {
@@ -324,7 +330,7 @@ func TestResizeLargestColumnWithColors(t *testing.T) {
 	tb.AddRow(Row{"1", color1})
 	tb.AddRow(Row{"2", color2})
 	tb.AddRow(Row{"3", color3})
-	sizes := tb.resizeLargestColumn(11)
+	rows, sizes := tb.resizeLargestColumn(&TableConfig, 11)
 	assert.Equal(t, []int{1, 3}, sizes)
 	redInit := "\033[0;31;10m"
 	colorReset := "\033[0m"
@@ -334,37 +340,37 @@ func TestResizeLargestColumnWithColors(t *testing.T) {
 		redInit + "ef↵" + colorResetBreak +
 		redInit + "gh↵" + colorResetBreak +
 		redInit + "ij↵" + colorResetBreak +
-		redInit + "k" + colorReset}, tb.rows[0])
+		redInit + "k" + colorReset}, rows[0])
 	assert.Equal(t, Row{"2", redInit + "12↵" + colorResetBreak +
 		redInit + "34↵" + colorResetBreak +
 		redInit + "56↵" + colorResetBreak +
 		redInit + "78↵" + colorResetBreak +
-		redInit + "90" + colorReset}, tb.rows[1])
+		redInit + "90" + colorReset}, rows[1])
 	assert.Equal(t, Row{"3", "12↵\n" +
 		"3" + redInit + "4↵" + colorResetBreak +
 		redInit + "56↵" + colorResetBreak +
 		redInit + "78↵" + colorResetBreak +
 		redInit + "9" + colorReset + "0↵\n" +
-		"12"}, tb.rows[2])
+		"12"}, rows[2])
 }
 
 func TestResizeLargestColumnUnicode(t *testing.T) {
 	tb := NewTable()
 	tb.AddRow(Row{"1", "åß∂¬ƒ˚©“œ¡™"})
 	tb.AddRow(Row{"2", "åß∂¬ƒ˚©“œ¡"})
-	sizes := tb.resizeLargestColumn(11)
+	rows, sizes := tb.resizeLargestColumn(&TableConfig, 11)
 	assert.Equal(t, []int{1, 3}, sizes)
 	assert.Equal(t, Row{"1", `åß↵
 ∂¬↵
 ƒ˚↵
 ©“↵
 œ¡↵
-™`}, tb.rows[0])
+™`}, rows[0])
 	assert.Equal(t, Row{"2", `åß↵
 ∂¬↵
 ƒ˚↵
 ©“↵
-œ¡`}, tb.rows[1])
+œ¡`}, rows[1])
 }
 
 func TestColoredString(t *testing.T) {
@@ -383,10 +389,10 @@ func TestColoredString(t *testing.T) {
 	assert.Equal(t, expected, table.String())
 }
 
-// TestRuneLenWithFatihColorFormats tests that runeLen correctly handles
+// TestDisplayWidthWithFatihColorFormats tests that displayWidth correctly handles
 // all ANSI escape sequences produced by github.com/fatih/color library.
 // This prevents regression when handling colored output.
-func TestRuneLenWithFatihColorFormats(t *testing.T) {
+func TestDisplayWidthWithFatihColorFormats(t *testing.T) {
 	tests := []struct {
 		name     string
 		input    string
@@ -501,8 +507,8 @@ func TestRuneLenWithFatihColorFormats(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := runeLen(tt.input)
-			assert.Equal(t, tt.expected, got, "runeLen(%q) = %d, want %d", tt.input, got, tt.expected)
+			got := displayWidth(tt.input)
+			assert.Equal(t, tt.expected, got, "displayWidth(%q) = %d, want %d", tt.input, got, tt.expected)
 		})
 	}
 }
@@ -553,7 +559,7 @@ func TestTableColumnWidthWithFatihColor(t *testing.T) {
 			for _, row := range tt.rows {
 				table.AddRow(row)
 			}
-			sizes := table.columnsSize()
+			sizes := table.columnsSize(&TableConfig)
 			assert.Equal(t, tt.expectedSizes, sizes)
 		})
 	}
@@ -564,19 +570,19 @@ func TestResizeLargestColumnOnWhitespace(t *testing.T) {
 	tb.AddRow(Row{"1", "abc def ghi jk"})
 	tb.AddRow(Row{"2", "12 3 456 7890"})
 	tb.AddRow(Row{"3", "1 2 3 4"})
-	sizes := tb.resizeLargestColumn(12)
+	rows, sizes := tb.resizeLargestColumn(&TableConfig, 12)
 	assert.Equal(t, []int{1, 4}, sizes)
 	assert.Equal(t, Row{"1", `abc↵
 def↵
 ghi↵
-jk`}, tb.rows[0])
+jk`}, rows[0])
 	assert.Equal(t, Row{"2", `12 ↵
 3  ↵
 456↵
 789↵
-0`}, tb.rows[1])
+0`}, rows[1])
 	assert.Equal(t, Row{"3", `1 2↵
-3 4`}, tb.rows[2])
+3 4`}, rows[2])
 }
 
 func TestResizeLargestColumnOnAnyWithBreakAny(t *testing.T) {
@@ -586,21 +592,21 @@ func TestResizeLargestColumnOnAnyWithBreakAny(t *testing.T) {
 	tb.AddRow(Row{"1", "abc def ghi jk"})
 	tb.AddRow(Row{"2", "12 3 456 7890"})
 	tb.AddRow(Row{"3", "1 2 3 4"})
-	sizes := tb.resizeLargestColumn(12)
+	rows, sizes := tb.resizeLargestColumn(&TableConfig, 12)
 	assert.Equal(t, []int{1, 4}, sizes)
 	assert.Equal(t, Row{"1", `abc↵
  de↵
 f g↵
 hi ↵
-jk`}, tb.rows[0])
+jk`}, rows[0])
 	assert.Equal(t, Row{"2", `12 ↵
 3 4↵
 56 ↵
 789↵
-0`}, tb.rows[1])
+0`}, rows[1])
 	assert.Equal(t, Row{"3", `1 2↵
  3 ↵
-4`}, tb.rows[2])
+4`}, rows[2])
 }
 
 func TestResizeLargestColumnOnBreakableChars(t *testing.T) {
@@ -608,21 +614,21 @@ func TestResizeLargestColumnOnBreakableChars(t *testing.T) {
 	tb.AddRow(Row{"1", "abc:def ghi jk"})
 	tb.AddRow(Row{"2", "12:3 456 7890"})
 	tb.AddRow(Row{"3", "1 2 3: 4"})
-	sizes := tb.resizeLargestColumn(12)
+	rows, sizes := tb.resizeLargestColumn(&TableConfig, 12)
 	assert.Equal(t, []int{1, 4}, sizes)
 	assert.Equal(t, Row{"1", `abc↵
 :de↵
 f  ↵
 ghi↵
-jk`}, tb.rows[0])
+jk`}, rows[0])
 	assert.Equal(t, Row{"2", `12:↵
 3  ↵
 456↵
 789↵
-0`}, tb.rows[1])
+0`}, rows[1])
 	assert.Equal(t, Row{"3", `1 2↵
 3: ↵
-4`}, tb.rows[2])
+4`}, rows[2])
 }
 
 func TestStringTabWriter(t *testing.T) {
@@ -805,10 +811,10 @@ func TestStringTabWriterWithANSIColors(t *testing.T) {
 	assert.Contains(t, output, "error")
 	assert.Contains(t, output, "…")
 
-	assert.Equal(t, 23, runeLen(lines[1])) // First row
-	assert.Equal(t, 20, runeLen(lines[2])) // Second row
-	assert.Equal(t, 23, runeLen(lines[3])) // Third row
-	assert.Equal(t, 0, runeLen(lines[4]))  // Trailing newline
+	assert.Equal(t, 23, displayWidth(lines[1])) // First row
+	assert.Equal(t, 20, displayWidth(lines[2])) // Second row
+	assert.Equal(t, 23, displayWidth(lines[3])) // Third row
+	assert.Equal(t, 0, displayWidth(lines[4]))  // Trailing newline
 }
 
 // Tests for expandRow function and TableWriterExpandRows flag
@@ -1269,13 +1275,13 @@ func TestUTF8BordersSeparatorPositions(t *testing.T) {
 	defer func() { TableConfig.UseUTF8Borders = false }()
 	table := NewTable()
 	buf := &strings.Builder{}
-	table.separator(buf, []int{3, 2}, sepTop)
+	table.separator(buf, &TableConfig, []int{3, 2}, sepTop, nil, nil)
 	assert.Equal(t, "┌─────┬────┐\n", buf.String())
 	buf.Reset()
-	table.separator(buf, []int{3, 2}, sepMiddle)
+	table.separator(buf, &TableConfig, []int{3, 2}, sepMiddle, nil, nil)
 	assert.Equal(t, "├─────┼────┤\n", buf.String())
 	buf.Reset()
-	table.separator(buf, []int{3, 2}, sepBottom)
+	table.separator(buf, &TableConfig, []int{3, 2}, sepBottom, nil, nil)
 	assert.Equal(t, "└─────┴────┘\n", buf.String())
 }
 
@@ -1288,6 +1294,197 @@ func TestUTF8BordersNoRows(t *testing.T) {
 	assert.Equal(t, expected, table.String())
 }
 
+func TestStringDoesNotMutateRows(t *testing.T) {
+	opts := RenderOptions{MaxTTYWidth: 11}
+	table := NewTable()
+	table.AddRow(Row{"1", "abcdefghijk"})
+	expected := `+---+-----+
+| 1 | ab↵ |
+|   | cd↵ |
+|   | ef↵ |
+|   | gh↵ |
+|   | ij↵ |
+|   | k   |
++---+-----+
+`
+	assert.Equal(t, expected, table.StringWithOptions(opts))
+	assert.Equal(t, expected, table.StringWithOptions(opts))
+	assert.Equal(t, Row{"1", "abcdefghijk"}, table.rows[0])
+	expected = "+---+-------------+\n| 1 | abcdefghijk |\n+---+-------------+\n"
+	assert.Equal(t, expected, table.StringWithOptions(RenderOptions{}))
+}
+
+func TestStringAtDifferentWidths(t *testing.T) {
+	table := NewTable()
+	table.AddRow(Row{"1", "abc def ghi"})
+	expected := `+---+------+
+| 1 | abc↵ |
+|   | def↵ |
+|   | ghi  |
++---+------+
+`
+	assert.Equal(t, expected, table.StringWithOptions(RenderOptions{MaxTTYWidth: 12}))
+	expected = `+---+----------+
+| 1 | abc def↵ |
+|   | ghi      |
++---+----------+
+`
+	assert.Equal(t, expected, table.StringWithOptions(RenderOptions{MaxTTYWidth: 16}))
+}
+
+func TestTableOptions(t *testing.T) {
+	table := NewTable()
+	table.Options = &RenderOptions{UseUTF8Borders: true}
+	table.AddRow(Row{"One", "1"})
+	assert.Equal(t, "┌─────┬───┐\n│ One │ 1 │\n└─────┴───┘\n", table.String())
+	assert.False(t, TableConfig.UseUTF8Borders)
+}
+
+func TestTableOptionsOverrideTableConfig(t *testing.T) {
+	TableConfig.UseTabWriter = true
+	defer func() { TableConfig.UseTabWriter = false }()
+	table := NewTable()
+	table.Options = &RenderOptions{}
+	table.AddRow(Row{"One", "1"})
+	assert.Equal(t, "+-----+---+\n| One | 1 |\n+-----+---+\n", table.String())
+}
+
+func TestStringWithOptions(t *testing.T) {
+	table := NewTable()
+	table.Options = &RenderOptions{UseUTF8Borders: true}
+	table.Headers = Row{"Word", "Number"}
+	table.AddRow(Row{"One", "1"})
+	expected := `WORD   NUMBER
+One    1
+`
+	assert.Equal(t, expected, table.StringWithOptions(RenderOptions{UseTabWriter: true}))
+	expected = `+------+--------+
+| Word | Number |
++------+--------+
+| One  | 1      |
++------+--------+
+`
+	assert.Equal(t, expected, table.StringWithOptions(RenderOptions{}))
+}
+
+func TestStringWithOptionsBorderColor(t *testing.T) {
+	table := NewTable()
+	table.AddRow(Row{"One", "1"})
+	opts := RenderOptions{BorderColorFunc: func(s string) string { return "[" + s + "]" }}
+	result := table.StringWithOptions(opts)
+	assert.Contains(t, result, "[+]")
+	assert.Contains(t, result, "[|]")
+	assert.NotContains(t, table.String(), "[")
+}
+
+func TestStringWithOptionsConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(utf8 bool) {
+			defer wg.Done()
+			table := NewTable()
+			table.AddRow(Row{"One", "1"})
+			expected := "+-----+---+\n| One | 1 |\n+-----+---+\n"
+			if utf8 {
+				expected = "┌─────┬───┐\n│ One │ 1 │\n└─────┴───┘\n"
+			}
+			assert.Equal(t, expected, table.StringWithOptions(RenderOptions{UseUTF8Borders: utf8}))
+		}(i%2 == 0)
+	}
+	wg.Wait()
+}
+
+type recordWriter struct {
+	writes []string
+}
+
+func (w *recordWriter) Write(p []byte) (int, error) {
+	w.writes = append(w.writes, string(p))
+	return len(p), nil
+}
+
+type failWriter struct {
+	failAfter int
+	writes    int
+}
+
+func (w *failWriter) Write(p []byte) (int, error) {
+	if w.writes == w.failAfter {
+		return 0, errors.New("write failed")
+	}
+	w.writes++
+	return len(p), nil
+}
+
+func TestRender(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Word", "Number"}
+	table.AddRow(Row{"One", "1"})
+	table.AddRow(Row{"Two", "2"})
+	var buf bytes.Buffer
+	err := table.Render(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, table.String(), buf.String())
+}
+
+func TestRenderLineByLine(t *testing.T) {
+	table := NewTable()
+	table.AddRow(Row{"One", "xxx\nyyy"})
+	table.AddRow(Row{"Two", "2"})
+	w := &recordWriter{}
+	err := table.Render(w)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"+-----+-----+\n",
+		"| One | xxx |\n",
+		"|     | yyy |\n",
+		"| Two | 2   |\n",
+		"+-----+-----+\n",
+	}, w.writes)
+}
+
+func TestRenderWriteError(t *testing.T) {
+	table := NewTable()
+	for i := 0; i < 10; i++ {
+		table.AddRow(Row{"One", "1"})
+	}
+	w := &failWriter{failAfter: 3}
+	err := table.Render(w)
+	assert.EqualError(t, err, "write failed")
+	assert.Equal(t, 3, w.writes)
+}
+
+func TestRenderTabWriterWriteError(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Word", "Number"}
+	for i := 0; i < 10; i++ {
+		table.AddRow(Row{"One", "1"})
+	}
+	w := &failWriter{failAfter: 2}
+	err := table.RenderWithOptions(w, RenderOptions{UseTabWriter: true})
+	assert.EqualError(t, err, "write failed")
+	assert.Equal(t, 2, w.writes)
+}
+
+func TestRenderEmptyTable(t *testing.T) {
+	w := &recordWriter{}
+	err := NewTable().Render(w)
+	assert.NoError(t, err)
+	assert.Empty(t, w.writes)
+}
+
+func TestWriteTo(t *testing.T) {
+	table := NewTable()
+	table.AddRow(Row{"One", "1"})
+	var buf bytes.Buffer
+	var _ io.WriterTo = table
+	n, err := table.WriteTo(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+	assert.Equal(t, "+-----+---+\n| One | 1 |\n+-----+---+\n", buf.String())
+}
+
 func BenchmarkString(b *testing.B) {
 	b.StopTimer()
 	table := NewTable()
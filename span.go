@@ -0,0 +1,187 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablecli
+
+// Spanned marks a cell covered by the cell on its left, which then spans
+// over it in the boxed and HTML renderers. The other renderers show it as
+// an empty cell, except for headers, which are named after the header on
+// their left in the exported formats.
+const Spanned = "\x00"
+
+// Colspan returns cell followed by the Spanned cells that make it span n
+// columns:
+//
+//	table.AddRow(append(tablecli.Row{"web"}, tablecli.Colspan("no quota", 2)...))
+func Colspan(cell string, n int) []string {
+	cells := []string{cell}
+	for range n - 1 {
+		cells = append(cells, Spanned)
+	}
+	return cells
+}
+
+// cellSpan returns the number of columns covered by the cell at index i of
+// row, including itself.
+func cellSpan(row Row, i int) int {
+	n := 1
+	for i+n < len(row) && row[i+n] == Spanned {
+		n++
+	}
+	return n
+}
+
+// unspan returns a copy of row with the Spanned cells left empty.
+func unspan(row Row) Row {
+	if row == nil {
+		return nil
+	}
+	cells := make(Row, len(row))
+	for i, cell := range row {
+		if cell != Spanned {
+			cells[i] = cell
+		}
+	}
+	return cells
+}
+
+// trimSpanned returns row without its trailing Spanned cells, used where a
+// cell spanning up to the last column is written without padding.
+func trimSpanned(row Row) Row {
+	end := len(row)
+	for end > 1 && row[end-1] == Spanned {
+		end--
+	}
+	return row[:end]
+}
+
+// spannedLike returns an empty row with the Spanned cells of row, used for
+// the extra lines of a row with multi-line cells.
+func spannedLike(row Row) Row {
+	cells := make(Row, len(row))
+	for i, cell := range row {
+		if cell == Spanned {
+			cells[i] = Spanned
+		}
+	}
+	return cells
+}
+
+// spanWidth returns the width available to a cell spanning the n columns
+// of sizes starting at i, which includes the padding and the vertical
+// borders between them.
+func (o *RenderOptions) spanWidth(sizes []int, i, n int) int {
+	n = min(n, len(sizes)-i)
+	width := (n - 1) * (2 + displayWidth(o.borderStyle().Vertical))
+	for _, sz := range sizes[i : i+n] {
+		width += sz
+	}
+	return width
+}
+
+// spanLimit is like spanWidth for the width limits of wrapColumns, where a
+// zero width means no limit, so it returns zero when any of the spanned
+// columns has no limit.
+func (o *RenderOptions) spanLimit(widths []int, i, n int) int {
+	if n == 1 {
+		return widths[i]
+	}
+	for _, width := range widths[i:min(i+n, len(widths))] {
+		if width <= 0 {
+			return 0
+		}
+	}
+	return o.spanWidth(widths, i, n)
+}
+
+// widenForSpans widens the columns of sizes covered by cells spanning
+// several columns in rows so each of them fits its text, giving the missing
+// width to the widest of the spanned columns, the last one on ties.
+func (o *RenderOptions) widenForSpans(sizes []int, rows ...Row) {
+	for _, row := range rows {
+		for i := 0; i < len(row) && i < len(sizes); i++ {
+			n := min(cellSpan(row, i), len(sizes)-i)
+			if n == 1 {
+				continue
+			}
+			if missing := cellWidth(row[i]) - o.spanWidth(sizes, i, n); missing > 0 {
+				widest := i
+				for k := i + 1; k < i+n; k++ {
+					if sizes[k] >= sizes[widest] {
+						widest = k
+					}
+				}
+				sizes[widest] += missing
+			}
+			i += n - 1
+		}
+	}
+}
+
+// junctionVariants maps the cross glyphs of the border presets to the
+// glyphs drawn where the vertical border only continues upwards or
+// downwards.
+var junctionVariants = map[string][2]string{
+	"┼": {"┴", "┬"},
+	"╬": {"╩", "╦"},
+	"╋": {"┻", "┳"},
+	"╪": {"╧", "╤"},
+	"╫": {"╨", "╥"},
+}
+
+// openBoundary reports whether the vertical border before column i is drawn
+// in row, which is the case unless the cell at i is Spanned. A nil row has
+// every boundary open.
+func openBoundary(row Row, i int) bool {
+	return i >= len(row) || row[i] != Spanned
+}
+
+// junctions returns the glyph drawn between each pair of columns on line
+// at pos, given the rows above and below it. Junctions under spanned cells
+// are replaced by the horizontal glyph or, on lines between rows, by the
+// variant of the cross glyph joining only the open side. The top and bottom
+// lines only look at the row they border.
+func junctions(line BorderLine, pos separatorPosition, n int, above, below Row) []string {
+	glyphs := make([]string, max(n-1, 0))
+	if line.Cross == "" {
+		return glyphs
+	}
+	for i := range glyphs {
+		up, down := openBoundary(above, i+1), openBoundary(below, i+1)
+		switch pos {
+		case sepTop:
+			up = down
+		case sepBottom:
+			down = up
+		}
+		switch {
+		case up && down:
+			glyphs[i] = line.Cross
+		case up:
+			glyphs[i] = junctionGlyph(line.Cross, 0)
+		case down:
+			glyphs[i] = junctionGlyph(line.Cross, 1)
+		default:
+			glyphs[i] = line.Horizontal
+		}
+	}
+	return glyphs
+}
+
+// junctionGlyph returns the upwards (0) or downwards (1) variant of cross,
+// or cross itself when it has none, as is the case of the ASCII "+".
+func junctionGlyph(cross string, side int) string {
+	if variants, ok := junctionVariants[cross]; ok {
+		return variants[side]
+	}
+	return cross
+}
+
+// firstRow returns the first row of rows, or nil when there is none.
+func firstRow(rows rowSlice) Row {
+	if len(rows) == 0 {
+		return nil
+	}
+	return rows[0]
+}
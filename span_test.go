@@ -0,0 +1,213 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablecli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestColspan(t *testing.T) {
+	assert.Equal(t, []string{"a", Spanned, Spanned}, Colspan("a", 3))
+	assert.Equal(t, []string{"a"}, Colspan("a", 1))
+	assert.Equal(t, []string{"a"}, Colspan("a", 0))
+}
+
+func TestCellSpan(t *testing.T) {
+	row := Row{"a", Spanned, "b", "c", Spanned, Spanned}
+	assert.Equal(t, 2, cellSpan(row, 0))
+	assert.Equal(t, 1, cellSpan(row, 2))
+	assert.Equal(t, 3, cellSpan(row, 3))
+	assert.Equal(t, Row{"a", "", "b", "c", "", ""}, unspan(row))
+}
+
+func TestWidenForSpans(t *testing.T) {
+	sizes := []int{3, 1, 1}
+	TableConfig.widenForSpans(sizes, append(Row{"x"}, Colspan("twelve chars", 2)...))
+	assert.Equal(t, []int{3, 1, 8}, sizes)
+	sizes = []int{3, 6, 2}
+	TableConfig.widenForSpans(sizes, Colspan("a cell wider than all", 3))
+	assert.Equal(t, []int{3, 10, 2}, sizes)
+	sizes = []int{3, 6, 2}
+	TableConfig.widenForSpans(sizes, Colspan("narrow", 3))
+	assert.Equal(t, []int{3, 6, 2}, sizes)
+}
+
+func TestJunctions(t *testing.T) {
+	line := BorderSingle.Middle
+	row := Row{"a", "b", "c"}
+	spanned := Row{"a", Spanned, "c"}
+	assert.Equal(t, []string{"┼", "┼"}, junctions(line, sepMiddle, 3, row, row))
+	assert.Equal(t, []string{"┴", "┼"}, junctions(line, sepMiddle, 3, row, spanned))
+	assert.Equal(t, []string{"┬", "┼"}, junctions(line, sepMiddle, 3, spanned, row))
+	assert.Equal(t, []string{"─", "┼"}, junctions(line, sepMiddle, 3, spanned, spanned))
+	assert.Equal(t, []string{"┼", "┼"}, junctions(line, sepMiddle, 3, nil, nil))
+	assert.Equal(t, []string{"─", "┬"}, junctions(BorderSingle.Top, sepTop, 3, row, spanned))
+	assert.Equal(t, []string{"─", "┴"}, junctions(BorderSingle.Bottom, sepBottom, 3, spanned, row))
+	assert.Equal(t, []string{"+", "+"}, junctions(BorderASCII.Middle, sepMiddle, 3, row, spanned))
+	assert.Equal(t, []string{"-", "+"}, junctions(BorderASCII.Top, sepTop, 3, nil, spanned))
+	assert.Equal(t, []string{"", ""}, junctions(BorderLine{Horizontal: "-"}, sepMiddle, 3, row, spanned))
+}
+
+func TestStringColumnSpans(t *testing.T) {
+	style := BorderASCII
+	table := NewTable()
+	table.Options = &RenderOptions{BorderStyle: &style}
+	table.Headers = append(Row{"App"}, Colspan("Resources", 2)...)
+	table.AddRow(Row{"web", "1", "512Mi"})
+	table.AddRow(Colspan("workers: disabled", 3))
+	table.AddRow(append(Row{"api"}, Colspan("unlimited", 2)...))
+	expected := `+-----+-------------+
+| App | Resources   |
++-----+---+---------+
+| web | 1 | 512Mi   |
+| workers: disabled |
+| api | unlimited   |
++-----+-------------+
+`
+	assert.Equal(t, expected, table.String())
+}
+
+func TestStringColumnSpansUTF8LineSeparator(t *testing.T) {
+	style := BorderSingle
+	table := NewTable()
+	table.Options = &RenderOptions{BorderStyle: &style}
+	table.LineSeparator = true
+	table.Headers = append(Row{"App"}, Colspan("Resources", 2)...)
+	table.AddRow(Row{"web", "1", "512Mi"})
+	table.AddRow(Colspan("workers: disabled", 3))
+	table.AddRow(append(Row{"api"}, Colspan("unlimited", 2)...))
+	expected := `┌─────┬─────────────┐
+│ App │ Resources   │
+├─────┼───┬─────────┤
+│ web │ 1 │ 512Mi   │
+├─────┴───┴─────────┤
+│ workers: disabled │
+├─────┬─────────────┤
+│ api │ unlimited   │
+└─────┴─────────────┘
+`
+	assert.Equal(t, expected, table.String())
+}
+
+func TestStringColumnSpansDouble(t *testing.T) {
+	style := BorderDouble
+	table := NewTable()
+	table.Options = &RenderOptions{BorderStyle: &style}
+	table.LineSeparator = true
+	table.Headers = append(Row{"App"}, Colspan("Resources", 2)...)
+	table.AddRow(Row{"web", "1", "512Mi"})
+	table.AddRow(Colspan("workers: disabled", 3))
+	table.AddRow(append(Row{"api"}, Colspan("unlimited", 2)...))
+	lines := strings.Split(table.String(), "\n")
+	assert.Equal(t, "╔═════╦═════════════╗", lines[0])
+	assert.Equal(t, "╠═════╬═══╦═════════╣", lines[2])
+	assert.Equal(t, "╠═════╩═══╩═════════╣", lines[4])
+	assert.Equal(t, "╠═════╦═════════════╣", lines[6])
+	assert.Equal(t, "╚═════╩═════════════╝", lines[8])
+}
+
+func TestStringColumnSpanMultiLine(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"App", "CPU", "Memory"}
+	table.AddRow(append(Row{"api"}, Colspan("line one\nline two", 2)...))
+	table.AddRow(Row{"web", "1", "512Mi"})
+	expected := `+-----+-----+--------+
+| App | CPU | Memory |
++-----+-----+--------+
+| api | line one     |
+|     | line two     |
+| web | 1   | 512Mi  |
++-----+-----+--------+
+`
+	assert.Equal(t, expected, table.String())
+}
+
+func TestStringColumnSpanWrap(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"A", "B", "C"}
+	table.AddRow(append(Row{"x"}, Colspan("a long spanning cell that must wrap over two columns", 2)...))
+	table.AddRow(Row{"y", "b", "c"})
+	expected := `+---+---+--------------------+
+| A | B | C                  |
++---+---+--------------------+
+| x | a long spanning cell ↵ |
+|   | that must wrap over  ↵ |
+|   | two columns            |
+| y | b | c                  |
++---+---+--------------------+
+`
+	for _, shrink := range []bool{false, true} {
+		table.Options = &RenderOptions{MaxTTYWidth: 30, ProportionalShrink: shrink}
+		assert.Equal(t, expected, table.String())
+	}
+}
+
+func TestColumnSpansOtherRenderers(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"App", "CPU", "Memory"}
+	table.AddRow(append(Row{"api"}, Colspan("unlimited", 2)...))
+	table.Column(1).Aggregate = AggregateCount
+	var html strings.Builder
+	assert.NoError(t, table.RenderHTML(&html))
+	assert.Contains(t, html.String(), `<tr><td>api</td><td colspan="2">unlimited</td></tr>`)
+	assert.Contains(t, html.String(), `<tr><td></td><td>1</td><td></td></tr>`)
+	var csv strings.Builder
+	assert.NoError(t, table.RenderCSV(&csv))
+	assert.Equal(t, "App,CPU,Memory\napi,unlimited,\n", csv.String())
+	table.Options = &RenderOptions{Expanded: true}
+	assert.Equal(t, "-[ RECORD 1 ]-----\nApp    | api\nCPU    | unlimited\nMemory |\n-[ FOOTER 1 ]-----\nApp    |\nCPU    | 1\nMemory |\n", table.String())
+}
+
+func TestStringColumnSpansWithoutBorders(t *testing.T) {
+	tests := []struct {
+		opts     RenderOptions
+		expected string
+	}{
+		{RenderOptions{NoColumnSeparators: true}, "+-------------------------+\n| x  y  z                 |\n| 1  a long spanning text |\n+-------------------------+\n"},
+		{RenderOptions{NoOuterBorder: true}, " x | y | z\n 1 | a long spanning text\n"},
+	}
+	for _, tt := range tests {
+		table := NewTable()
+		table.AddRow(Row{"x", "y", "z"})
+		table.AddRow(append(Row{"1"}, Colspan("a long spanning text", 2)...))
+		table.Options = &tt.opts
+		assert.Equal(t, tt.expected, table.String())
+	}
+}
+
+func TestColumnSpansExportedHeaders(t *testing.T) {
+	table := NewTable()
+	table.Headers = append(Row{"App"}, Colspan("Resources", 3)...)
+	table.AddRow(Row{"web", "1", "512Mi", "2Gi"})
+	assert.Equal(t, []string{"App", "Resources", "Resources 2", "Resources 3"}, table.exportHeaders())
+	data, err := json.Marshal(table)
+	assert.NoError(t, err)
+	var decoded []map[string]string
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	expected := []map[string]string{{"App": "web", "Resources": "1", "Resources 2": "512Mi", "Resources 3": "2Gi"}}
+	assert.Equal(t, expected, decoded)
+	var buf strings.Builder
+	assert.NoError(t, table.RenderYAML(&buf))
+	decoded = nil
+	assert.NoError(t, yaml.Unmarshal([]byte(buf.String()), &decoded))
+	assert.Equal(t, expected, decoded)
+}
+
+func TestStringColumnSpansTabWriter(t *testing.T) {
+	table := NewTable()
+	table.Options = &RenderOptions{UseTabWriter: true}
+	table.Headers = append(Row{"App"}, Colspan("Resources", 2)...)
+	table.AddRow(Row{"web", "1", "512Mi"})
+	table.AddRow(append(Row{"api"}, Colspan("unlimited", 2)...))
+	expected := "APP   RESOURCES\n" +
+		"web   1           512Mi\n" +
+		"api   unlimited\n"
+	assert.Equal(t, expected, table.String())
+}
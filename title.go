@@ -0,0 +1,103 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablecli
+
+import (
+	"io"
+	"strings"
+)
+
+// frameText returns the title or the caption drawn at pos, along with its
+// alignment.
+func (t *Table) frameText(pos separatorPosition) (string, Alignment) {
+	switch pos {
+	case sepTop:
+		return t.Title, t.TitleAlign
+	case sepBottom:
+		return t.Caption, t.CaptionAlign
+	}
+	return "", AlignDefault
+}
+
+// framedLine returns the glyphs of line for the given column sizes and
+// junctions with text drawn over them, or false when there is no room for
+// the text.
+func framedLine(opts *RenderOptions, line BorderLine, sizes []int, crosses []string, text string, align Alignment) (string, bool) {
+	glyphs := lineGlyphs(line, sizes, crosses)
+	innerStart, innerEnd := 0, len(glyphs)
+	if line.Left != "" {
+		innerStart++
+	}
+	if line.Right != "" {
+		innerEnd--
+	}
+	// the text is surrounded by a space on each side and keeps at least
+	// one horizontal glyph between it and the corners.
+	room := innerEnd - innerStart - 4
+	if room < 1 {
+		return "", false
+	}
+	text = fitText(text, room)
+	label := " " + text + " "
+	width := displayWidth(label)
+	var start int
+	switch align {
+	case AlignRight:
+		start = innerEnd - 1 - width
+	case AlignCenter:
+		start = innerStart + (innerEnd-innerStart-width)/2
+	default:
+		start = innerStart + 1
+	}
+	return opts.borderColor(strings.Join(glyphs[:start], "")) +
+		label +
+		opts.borderColor(strings.Join(glyphs[start+width:], "")), true
+}
+
+// lineGlyphs returns the glyphs of line for the given column sizes, with
+// crosses drawn between the columns.
+func lineGlyphs(line BorderLine, sizes []int, crosses []string) []string {
+	var glyphs []string
+	if line.Left != "" {
+		glyphs = append(glyphs, line.Left)
+	}
+	for i, sz := range sizes {
+		if i > 0 && crosses[i-1] != "" {
+			glyphs = append(glyphs, crosses[i-1])
+		}
+		for range sz + 2 {
+			glyphs = append(glyphs, line.Horizontal)
+		}
+	}
+	if line.Right != "" {
+		glyphs = append(glyphs, line.Right)
+	}
+	return glyphs
+}
+
+// fitText joins the lines of text and truncates it to width.
+func fitText(text string, width int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if displayWidth(text) > width {
+		text = truncateLine(text, width, OverflowTruncateEnd)
+	}
+	return text
+}
+
+// writeTextLine writes text on a line of its own, aligned in width.
+func writeTextLine(buf io.StringWriter, text string, align Alignment, width int) {
+	if width > 0 {
+		text = fitText(text, width)
+	}
+	free := max(width-displayWidth(text), 0)
+	switch align {
+	case AlignRight:
+		buf.WriteString(strings.Repeat(" ", free))
+	case AlignCenter:
+		buf.WriteString(strings.Repeat(" ", free/2))
+	}
+	buf.WriteString(text)
+	buf.WriteString("\n")
+}
@@ -0,0 +1,175 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablecli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStringTitleAndCaption(t *testing.T) {
+	table := NewTable()
+	table.Title = "Units"
+	table.Caption = "2 units"
+	table.Headers = Row{"Name", "Status"}
+	table.AddRow(Row{"web-1", "started"})
+	table.AddRow(Row{"web-2", "stopped"})
+	expected := `+- Units ---------+
+| Name  | Status  |
++-------+---------+
+| web-1 | started |
+| web-2 | stopped |
++- 2 units -------+
+`
+	assert.Equal(t, expected, table.String())
+}
+
+func TestStringTitleAlignment(t *testing.T) {
+	table := NewTable()
+	table.Title = "Units"
+	table.Caption = "2 units"
+	table.Headers = Row{"Name", "Status"}
+	table.AddRow(Row{"web-1", "started"})
+	table.AddRow(Row{"web-2", "stopped"})
+	table.TitleAlign = AlignCenter
+	table.CaptionAlign = AlignRight
+	table.Options = &RenderOptions{UseUTF8Borders: true}
+	table.LineSeparator = true
+	expected := `┌───── Units ─────┐
+│ Name  │ Status  │
+├───────┼─────────┤
+│ web-1 │ started │
+├───────┼─────────┤
+│ web-2 │ stopped │
+└─────── 2 units ─┘
+`
+	assert.Equal(t, expected, table.String())
+}
+
+func TestStringTitleTruncated(t *testing.T) {
+	table := NewTable()
+	table.Title = "Service instances\nof the app"
+	table.Headers = Row{"Name"}
+	table.AddRow(Row{"mysql-1"})
+	expected := `+- Serv… -+
+| Name    |
++---------+
+| mysql-1 |
++---------+
+`
+	assert.Equal(t, expected, table.String())
+	table.Title = "Service instances"
+	table.Headers = Row{"N"}
+	table.rows = nil
+	table.AddRow(Row{"m"})
+	assert.Equal(t, "+---+\n| N |\n+---+\n| m |\n+---+\n", table.String())
+}
+
+func TestStringTitleWithColors(t *testing.T) {
+	color.NoColor = false
+	defer func() { color.NoColor = true }()
+	table := NewTable()
+	table.Title = color.New(color.FgRed).Sprint("Units")
+	table.Headers = Row{"Name", "Status"}
+	table.AddRow(Row{"web-1", "started"})
+	table.AddRow(Row{"web-2", "stopped"})
+	table.Options = &RenderOptions{BorderColorFunc: func(s string) string { return "<" + s + ">" }}
+	firstLine := strings.SplitN(table.String(), "\n", 2)[0]
+	assert.Equal(t, "<+-> \x1b[31mUnits\x1b[0m <---------+>", firstLine)
+}
+
+func TestStringTitleWithoutFrame(t *testing.T) {
+	table := NewTable()
+	table.Title = "Units"
+	table.Caption = "2 units"
+	table.Headers = Row{"Name", "Status"}
+	table.AddRow(Row{"web-1", "started"})
+	table.AddRow(Row{"web-2", "stopped"})
+	table.TitleAlign = AlignCenter
+	table.Options = &RenderOptions{BorderStyle: &BorderMarkdown}
+	expected := `       Units
+| Name  | Status  |
+|-------|---------|
+| web-1 | started |
+| web-2 | stopped |
+2 units
+`
+	assert.Equal(t, expected, table.String())
+}
+
+func TestStringTabWriterTitle(t *testing.T) {
+	table := NewTable()
+	table.Title = "Units"
+	table.Caption = "2 units"
+	table.Headers = Row{"Name", "Status"}
+	table.AddRow(Row{"web-1", "started"})
+	table.AddRow(Row{"web-2", "stopped"})
+	table.Options = &RenderOptions{UseTabWriter: true}
+	table.CaptionAlign = AlignRight
+	expected := "Units\n" +
+		"NAME    STATUS\n" +
+		"web-1   started\n" +
+		"web-2   stopped\n" +
+		"        2 units\n"
+	assert.Equal(t, expected, table.String())
+}
+
+func TestExpandedTitle(t *testing.T) {
+	table := NewTable()
+	table.Title = "Units"
+	table.Caption = "2 units"
+	table.Headers = Row{"Name", "Status"}
+	table.AddRow(Row{"web-1", "started"})
+	table.AddRow(Row{"web-2", "stopped"})
+	table.Options = &RenderOptions{Expanded: true}
+	expected := `Units
+-[ RECORD 1 ]---
+Name   | web-1
+Status | started
+-[ RECORD 2 ]---
+Name   | web-2
+Status | stopped
+2 units
+`
+	assert.Equal(t, expected, table.String())
+}
+
+func TestStructuredTitle(t *testing.T) {
+	table := NewTable()
+	table.Title = "Units | app"
+	table.Caption = "2 units"
+	table.Headers = Row{"Name", "Status"}
+	table.AddRow(Row{"web-1", "started"})
+	table.AddRow(Row{"web-2", "stopped"})
+
+	var b strings.Builder
+	assert.NoError(t, table.RenderMarkdown(&b))
+	assert.Equal(t, "**Units \\| app**\n\n"+
+		"| Name  | Status  |\n"+
+		"| ----- | ------- |\n"+
+		"| web-1 | started |\n"+
+		"| web-2 | stopped |\n"+
+		"\n*2 units*\n", b.String())
+
+	b.Reset()
+	assert.NoError(t, table.RenderYAML(&b))
+	assert.Equal(t, "# Units | app\n"+
+		"- Name: web-1\n  Status: started\n"+
+		"- Name: web-2\n  Status: stopped\n"+
+		"# 2 units\n", b.String())
+
+	b.Reset()
+	table.CaptionAlign = AlignCenter
+	assert.NoError(t, table.RenderHTML(&b))
+	assert.Contains(t, b.String(), "<table>\n<caption>Units | app</caption>\n<thead>")
+	assert.Contains(t, b.String(), "</tbody>\n<tfoot>\n<tr><td colspan=\"2\" style=\"text-align: center\">2 units</td></tr>\n</tfoot>\n</table>\n")
+
+	b.Reset()
+	assert.NoError(t, table.RenderCSV(&b))
+	assert.Equal(t, "Name,Status\nweb-1,started\nweb-2,stopped\n", b.String())
+}
@@ -0,0 +1,204 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablecli
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const zeroWidthJoiner = '\u200d'
+
+// wideRanges holds the East Asian Wide and Fullwidth code points, which
+// take two terminal cells, including the emoji with default emoji
+// presentation.
+var wideRanges = [][2]rune{
+	{0x1100, 0x115f}, {0x231a, 0x231b}, {0x2329, 0x232a}, {0x23e9, 0x23ec},
+	{0x23f0, 0x23f0}, {0x23f3, 0x23f3}, {0x25fd, 0x25fe}, {0x2614, 0x2615},
+	{0x2648, 0x2653}, {0x267f, 0x267f}, {0x2693, 0x2693}, {0x26a1, 0x26a1},
+	{0x26aa, 0x26ab}, {0x26bd, 0x26be}, {0x26c4, 0x26c5}, {0x26ce, 0x26ce},
+	{0x26d4, 0x26d4}, {0x26ea, 0x26ea}, {0x26f2, 0x26f3}, {0x26f5, 0x26f5},
+	{0x26fa, 0x26fa}, {0x26fd, 0x26fd}, {0x2705, 0x2705}, {0x270a, 0x270b},
+	{0x2728, 0x2728}, {0x274c, 0x274c}, {0x274e, 0x274e}, {0x2753, 0x2755},
+	{0x2757, 0x2757}, {0x2795, 0x2797}, {0x27b0, 0x27b0}, {0x27bf, 0x27bf},
+	{0x2b1b, 0x2b1c}, {0x2b50, 0x2b50}, {0x2b55, 0x2b55}, {0x2e80, 0x2e99},
+	{0x2e9b, 0x2ef3}, {0x2f00, 0x2fd5}, {0x2ff0, 0x2ffb}, {0x3000, 0x303e},
+	{0x3041, 0x3096}, {0x3099, 0x30ff}, {0x3105, 0x312f}, {0x3131, 0x318e},
+	{0x3190, 0x31e3}, {0x31f0, 0x321e}, {0x3220, 0x3247}, {0x3250, 0x4dbf},
+	{0x4e00, 0xa48c}, {0xa490, 0xa4c6}, {0xa960, 0xa97c}, {0xac00, 0xd7a3},
+	{0xf900, 0xfaff}, {0xfe10, 0xfe19}, {0xfe30, 0xfe52}, {0xfe54, 0xfe66},
+	{0xfe68, 0xfe6b}, {0xff01, 0xff60}, {0xffe0, 0xffe6}, {0x16fe0, 0x16fe4},
+	{0x16ff0, 0x16ff1}, {0x17000, 0x187f7}, {0x18800, 0x18cd5}, {0x18d00, 0x18d08},
+	{0x1aff0, 0x1b122}, {0x1b150, 0x1b152}, {0x1b164, 0x1b167}, {0x1b170, 0x1b2fb},
+	{0x1f004, 0x1f004}, {0x1f0cf, 0x1f0cf}, {0x1f18e, 0x1f18e}, {0x1f191, 0x1f19a},
+	{0x1f200, 0x1f202}, {0x1f210, 0x1f23b}, {0x1f240, 0x1f248}, {0x1f250, 0x1f251},
+	{0x1f260, 0x1f265}, {0x1f300, 0x1f320}, {0x1f32d, 0x1f335}, {0x1f337, 0x1f37c},
+	{0x1f37e, 0x1f393}, {0x1f3a0, 0x1f3ca}, {0x1f3cf, 0x1f3d3}, {0x1f3e0, 0x1f3f0},
+	{0x1f3f4, 0x1f3f4}, {0x1f3f8, 0x1f43e}, {0x1f440, 0x1f440}, {0x1f442, 0x1f4fc},
+	{0x1f4ff, 0x1f53d}, {0x1f54b, 0x1f54e}, {0x1f550, 0x1f567}, {0x1f57a, 0x1f57a},
+	{0x1f595, 0x1f596}, {0x1f5a4, 0x1f5a4}, {0x1f5fb, 0x1f64f}, {0x1f680, 0x1f6c5},
+	{0x1f6cc, 0x1f6cc}, {0x1f6d0, 0x1f6d2}, {0x1f6d5, 0x1f6d7}, {0x1f6dc, 0x1f6df},
+	{0x1f6eb, 0x1f6ec}, {0x1f6f4, 0x1f6fc}, {0x1f7e0, 0x1f7eb}, {0x1f7f0, 0x1f7f0},
+	{0x1f90c, 0x1f93a}, {0x1f93c, 0x1f945}, {0x1f947, 0x1f9ff}, {0x1fa70, 0x1fa7c},
+	{0x1fa80, 0x1fa88}, {0x1fa90, 0x1fabd}, {0x1fabf, 0x1fac5}, {0x1face, 0x1fadb},
+	{0x1fae0, 0x1fae8}, {0x1faf0, 0x1faf8}, {0x20000, 0x2fffd}, {0x30000, 0x3fffd},
+}
+
+func isWide(r rune) bool {
+	i := sort.Search(len(wideRanges), func(i int) bool { return wideRanges[i][1] >= r })
+	return i < len(wideRanges) && wideRanges[i][0] <= r
+}
+
+// runeWidth returns the number of terminal cells used by r on its own.
+func runeWidth(r rune) int {
+	switch {
+	case r < 0x20 || (r >= 0x7f && r < 0xa0):
+		return 0
+	case r < 0x300:
+		return 1
+	case r != 0xad && unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	case r >= 0x1160 && r <= 0x11ff:
+		return 0
+	case isWide(r):
+		return 2
+	}
+	return 1
+}
+
+// isGraphemeExtend reports whether r continues the grapheme cluster of the
+// runes before it, like combining marks, variation selectors and emoji
+// skin tone modifiers.
+func isGraphemeExtend(r rune) bool {
+	switch {
+	case r == zeroWidthJoiner || r == '\u200c':
+		return true
+	case r >= 0x1f3fb && r <= 0x1f3ff:
+		return true
+	case r >= 0xe0020 && r <= 0xe007f:
+		return true
+	case r >= 0x1160 && r <= 0x11ff, r >= 0xd7b0 && r <= 0xd7ff:
+		return true
+	}
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc)
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
+
+func isPictographic(r rune) bool {
+	return r >= 0x1f000 || unicode.Is(unicode.So, r)
+}
+
+// nextGrapheme returns the size in bytes and the display width of the
+// grapheme cluster at the start of s. It follows the main rules of UAX #29:
+// combining marks, ZWJ emoji sequences and flag pairs are kept together.
+func nextGrapheme(s string) (size, width int) {
+	r, size := utf8.DecodeRuneInString(s)
+	width = runeWidth(r)
+	if r == '\r' && strings.HasPrefix(s[size:], "\n") {
+		return size + 1, 0
+	}
+	regional := isRegionalIndicator(r)
+	afterJoiner := false
+	for size < len(s) {
+		next, n := utf8.DecodeRuneInString(s[size:])
+		switch {
+		case regional && isRegionalIndicator(next):
+			regional = false
+			width = 2
+		case afterJoiner && isPictographic(next):
+			afterJoiner = false
+		case isGraphemeExtend(next):
+			afterJoiner = next == zeroWidthJoiner
+			if next == '\ufe0f' && width == 1 {
+				// emoji presentation selector
+				width = 2
+			}
+		default:
+			return size, width
+		}
+		size += n
+		regional = regional && isRegionalIndicator(next)
+	}
+	return size, width
+}
+
+// textWidth returns the display width of s, which must not contain ANSI
+// escape sequences.
+func textWidth(s string) int {
+	ascii := true
+	for i := 0; i < len(s); i++ {
+		if s[i] < 0x20 || s[i] >= 0x7f {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		return len(s)
+	}
+	var width int
+	for len(s) > 0 {
+		size, w := nextGrapheme(s)
+		width += w
+		s = s[size:]
+	}
+	return width
+}
+
+// displayWidth returns the number of terminal cells used to display s,
+// ignoring ANSI color sequences. East Asian wide characters and emoji take
+// two cells, combining marks take none and grapheme clusters such as ZWJ
+// emoji sequences and flags are measured as a single character.
+func displayWidth(s string) int {
+	if strings.IndexByte(s, '\033') == -1 {
+		return textWidth(s)
+	}
+	var width int
+	start := 0
+	for _, pos := range ignoredPattern.FindAllStringIndex(s, -1) {
+		width += textWidth(s[start:pos[0]])
+		start = pos[1]
+	}
+	return width + textWidth(s[start:])
+}
+
+// textUnit is a piece of text handled as a whole when wrapping: a grapheme
+// cluster or an ANSI escape sequence. r holds the rune of single rune
+// clusters, so that break characters can be matched.
+type textUnit struct {
+	text  string
+	width int
+	r     rune
+}
+
+// textUnits splits line in the units textWrapper breaks lines at.
+func textUnits(line string) []textUnit {
+	var units []textUnit
+	addText := func(s string) {
+		for len(s) > 0 {
+			size, width := nextGrapheme(s)
+			unit := textUnit{text: s[:size], width: width}
+			if r, n := utf8.DecodeRuneInString(s); n == size {
+				unit.r = r
+			}
+			units = append(units, unit)
+			s = s[size:]
+		}
+	}
+	start := 0
+	if strings.IndexByte(line, '\033') != -1 {
+		for _, pos := range ignoredPattern.FindAllStringIndex(line, -1) {
+			addText(line[start:pos[0]])
+			units = append(units, textUnit{text: line[pos[0]:pos[1]]})
+			start = pos[1]
+		}
+	}
+	addText(line[start:])
+	return units
+}
@@ -0,0 +1,138 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablecli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+	}{
+		{"", 0},
+		{"abc", 3},
+		{"café", 4},
+		{"cafe\u0301", 4},
+		{"日本語", 6},
+		{"ｆｕｌｌ", 8},
+		{"한국어", 6},
+		{"🚀", 2},
+		{"ok ✅", 5},
+		{"👍🏽", 2},
+		{"👩\u200d💻", 2},
+		{"👨\u200d👩\u200d👧\u200d👦", 2},
+		{"🇧🇷🇯🇵", 4},
+		{"❤\ufe0f", 2},
+		{"\x1b[31m日本\x1b[0m", 4},
+		{"\x1b[31mred\x1b[0m tail", 8},
+		{"a\u200bb", 2},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, displayWidth(tt.input), "input %q", tt.input)
+	}
+}
+
+func TestNextGrapheme(t *testing.T) {
+	size, width := nextGrapheme("👩\u200d💻x")
+	assert.Equal(t, len("👩\u200d💻"), size)
+	assert.Equal(t, 2, width)
+	size, width = nextGrapheme("🇧🇷🇯🇵")
+	assert.Equal(t, len("🇧🇷"), size)
+	assert.Equal(t, 2, width)
+	size, width = nextGrapheme("e\u0301x")
+	assert.Equal(t, len("e\u0301"), size)
+	assert.Equal(t, 1, width)
+}
+
+func TestStringWideCharacters(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name", "Status"}
+	table.AddRow(Row{"日本語", "ok"})
+	table.AddRow(Row{"rocket", "🚀"})
+	table.AddRow(Row{"café", "👩\u200d💻"})
+	expected := "+--------+--------+\n" +
+		"| Name   | Status |\n" +
+		"+--------+--------+\n" +
+		"| 日本語 | ok     |\n" +
+		"| rocket | 🚀     |\n" +
+		"| café   | 👩\u200d💻     |\n" +
+		"+--------+--------+\n"
+	assert.Equal(t, expected, table.String())
+}
+
+func TestStringTabWriterWideCharacters(t *testing.T) {
+	table := NewTable()
+	table.Options = &RenderOptions{UseTabWriter: true}
+	table.Headers = Row{"NAME", "STATUS"}
+	table.AddRow(Row{"日本語", "ok"})
+	table.AddRow(Row{"abc", "🇧🇷"})
+	expected := "NAME     STATUS\n" +
+		"日本語   ok\n" +
+		"abc      🇧🇷\n"
+	assert.Equal(t, expected, table.String())
+}
+
+func TestWrapWideCharacters(t *testing.T) {
+	w := textWrapper{breakChars: DefaultBreakChars, marker: DefaultWrapMarker}
+	assert.Equal(t, "日本↵\n語で↵\nす", w.wrap("日本語です", 5))
+	assert.Equal(t, "日a↵\n本", w.wrap("日a本", 4))
+	assert.Equal(t, "👩\u200d💻👩\u200d💻↵\n👩\u200d💻", w.wrap("👩\u200d💻👩\u200d💻👩\u200d💻", 5))
+	assert.Equal(t, "e\u0301e\u0301↵\ne\u0301", w.wrap("e\u0301e\u0301e\u0301", 3))
+}
+
+func TestWrapWideCharactersWithColors(t *testing.T) {
+	color.NoColor = false
+	defer func() { color.NoColor = true }()
+	red := color.New(color.FgRed).SprintFunc()
+	w := textWrapper{breakChars: DefaultBreakChars, marker: DefaultWrapMarker}
+	result := w.wrap(red("日本語"), 5)
+	assert.Equal(t, "\x1b[31m日本↵\x1b[0m\n\x1b[31m語\x1b[0m", result)
+}
+
+func TestStringWrapsWideCharacters(t *testing.T) {
+	table := NewTable()
+	table.Options = &RenderOptions{MaxTTYWidth: 16}
+	table.Headers = Row{"ID", "Text"}
+	table.AddRow(Row{"1", "日本語のテキスト"})
+	for _, line := range strings.Split(strings.TrimSuffix(table.String(), "\n"), "\n") {
+		assert.LessOrEqual(t, displayWidth(line), 16, "line %q", line)
+	}
+}
+
+func TestWrapKeepsGraphemeClusters(t *testing.T) {
+	tests := []struct {
+		input      string
+		n          int
+		breakOnAny bool
+		expected   string
+	}{
+		{"éééé", 3, true, "éé↵\néé"},
+		{"nãoñ", 4, true, "não↵\nñ"},
+		{"a👩\u200d💻b", 3, true, "a↵\n👩\u200d💻↵\nb"},
+		{"👍🏽👍🏽", 3, true, "👍🏽↵\n👍🏽"},
+		{"🇧🇷🇯🇵🇺🇸", 5, true, "🇧🇷🇯🇵↵\n🇺🇸"},
+		{"x \u0301y", 3, false, "x \u0301↵\ny"},
+		{"👩\u200d💻 👩\u200d💻", 4, false, "👩\u200d💻 ↵\n👩\u200d💻"},
+	}
+	for _, tt := range tests {
+		w := textWrapper{breakOnAny: tt.breakOnAny, breakChars: DefaultBreakChars, marker: DefaultWrapMarker}
+		assert.Equal(t, tt.expected, w.wrap(tt.input, tt.n), "input %q", tt.input)
+	}
+}
+
+func TestWrapGraphemeClustersWithColors(t *testing.T) {
+	color.NoColor = false
+	defer func() { color.NoColor = true }()
+	green := color.New(color.FgGreen).SprintFunc()
+	w := textWrapper{breakOnAny: true, breakChars: DefaultBreakChars, marker: DefaultWrapMarker}
+	result := w.wrap(green("éé")+"👩\u200d💻", 3)
+	assert.Equal(t, "\x1b[32méé\x1b[0m↵\n👩\u200d💻", result)
+}
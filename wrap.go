@@ -0,0 +1,415 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablecli
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+const (
+	// DefaultBreakChars are the characters after which cells are wrapped
+	// when no other BreakChars are set.
+	DefaultBreakChars = " .:="
+	// PathBreakChars suits paths and URLs, breaking after slashes, query
+	// separators and dashes.
+	PathBreakChars = " /?&-"
+	// IdentifierBreakChars suits identifiers such as image names, UUIDs and
+	// snake_case or kebab-case names.
+	IdentifierBreakChars = " _-.:/"
+
+	// DefaultWrapMarker is added to the end of every line of a wrapped cell
+	// that continues on the next one.
+	DefaultWrapMarker = "↵"
+)
+
+// Overflow is the policy used for cells wider than their column.
+type Overflow int
+
+const (
+	// OverflowWrap breaks wide cells in several lines.
+	OverflowWrap Overflow = iota
+	// OverflowTruncateEnd keeps the start of wide cells, replacing the end
+	// with an ellipsis.
+	OverflowTruncateEnd
+	// OverflowTruncateMiddle keeps the start and the end of wide cells,
+	// which suits digests and UUIDs.
+	OverflowTruncateMiddle
+	// OverflowTruncateStart keeps the end of wide cells, which suits paths.
+	OverflowTruncateStart
+)
+
+const ellipsis = "…"
+
+// textWrapper holds the settings used to wrap the cells of a column.
+type textWrapper struct {
+	breakOnAny bool
+	breakChars string
+	marker     string
+	overflow   Overflow
+	balanced   bool
+	// noFill leaves out the spaces that line up the wrap markers, which
+	// would keep lines in columns that are not left aligned from being
+	// aligned.
+	noFill bool
+}
+
+// wrapper returns the textWrapper for column i, where the settings of its
+// ColumnSpec take precedence over the ones in opts.
+func (t *Table) wrapper(opts *RenderOptions, i int) textWrapper {
+	w := textWrapper{
+		breakOnAny: opts.BreakOnAny,
+		breakChars: DefaultBreakChars,
+		marker:     DefaultWrapMarker,
+		balanced:   opts.BalancedWrap,
+	}
+	if opts.BreakChars != "" {
+		w.breakChars = opts.BreakChars
+	}
+	if opts.WrapMarker != "" {
+		w.marker = opts.WrapMarker
+	}
+	if opts.NoWrapMarker {
+		w.marker = ""
+	}
+	spec := t.columnSpec(i)
+	w.noFill = spec.Align != AlignDefault && spec.Align != AlignLeft
+	w.overflow = spec.Overflow
+	w.balanced = w.balanced || spec.BalancedWrap
+	if spec.BreakChars != "" {
+		w.breakChars = spec.BreakChars
+	}
+	if spec.WrapMarker != "" {
+		w.marker = spec.WrapMarker
+	}
+	if spec.NoWrapMarker {
+		w.marker = ""
+	}
+	return w
+}
+
+// wrap breaks str in lines of at most n cells, including the wrap marker
+// added to every line that continues on the next one.
+func (w textWrapper) wrap(str string, n int) string {
+	if w.overflow != OverflowWrap {
+		return w.truncate(str, n)
+	}
+	n -= displayWidth(w.marker)
+	str = strings.TrimRightFunc(str, unicode.IsSpace)
+	lines := strings.Split(str, "\n")
+	var parts []string
+	for _, line := range lines {
+		line = strings.TrimRightFunc(line, unicode.IsSpace)
+		units := textUnits(line)
+		if w.balanced {
+			if balanced, ok := w.balancedLines(units, n); ok {
+				parts = append(parts, balanced...)
+				continue
+			}
+		}
+		parts = append(parts, w.greedyLines(units, n)...)
+	}
+	return redistributeColors(parts)
+}
+
+// greedyLines fills each line with as many units as possible, moving back
+// to the last break character when a line has to be broken.
+func (w textWrapper) greedyLines(units []textUnit, n int) []string {
+	var parts []string
+	var start, end int
+	for ; start < len(units); start = end {
+		width := 0
+		for end = start; end < len(units); end++ {
+			unitWidth := units[end].width
+			if unitWidth > 0 && width > 0 && width+unitWidth > n {
+				break
+			}
+			width += unitWidth
+		}
+		oldEnd := end
+		skipSpace := false
+		breakableChar := false
+		if !w.breakOnAny && end < len(units) {
+			for ; end > start; end-- {
+				if w.isBreakChar(units[end]) {
+					breakableChar = true
+					if units[end].r == ' ' {
+						skipSpace = true
+					} else if end < oldEnd {
+						end++
+					}
+					break
+				}
+			}
+			if !breakableChar {
+				end = oldEnd
+			}
+		}
+		var part strings.Builder
+		width = 0
+		for _, unit := range units[start:end] {
+			part.WriteString(unit.text)
+			width += unit.width
+		}
+		if breakableChar {
+			w.fill(&part, n-width)
+			if skipSpace {
+				end++
+			}
+		}
+		if end < len(units) {
+			part.WriteString(w.marker)
+		}
+		parts = append(parts, part.String())
+	}
+	return parts
+}
+
+// balancedLines breaks units at break characters in as few lines of at
+// most n cells as possible, minimizing the sum of the squared free space
+// at the end of the lines, so they have similar widths. It returns false
+// when some word is wider than n.
+func (w textWrapper) balancedLines(units []textUnit, n int) ([]string, bool) {
+	// a line ending at a break point has the units before end and the
+	// next line starts at next, skipping the space the line was broken at.
+	type breakPoint struct{ end, next int }
+	points := []breakPoint{{0, 0}}
+	for i := 1; i < len(units); i++ {
+		switch {
+		case units[i].r == ' ' && (w.breakOnAny || w.isBreakChar(units[i])):
+			points = append(points, breakPoint{i, i + 1})
+		case w.breakOnAny:
+			points = append(points, breakPoint{i, i})
+		case w.isBreakChar(units[i]) && i+1 < len(units):
+			points = append(points, breakPoint{i + 1, i + 1})
+		}
+	}
+	points = append(points, breakPoint{len(units), len(units)})
+	widths := make([]int, len(units)+1)
+	for i, unit := range units {
+		widths[i+1] = widths[i] + unit.width
+	}
+	// the best way to break the units before each point uses the fewest
+	// lines, then has the smallest sum of squared free space.
+	type cost struct{ lines, free int }
+	last := len(points) - 1
+	costs := make([]cost, len(points))
+	prev := make([]int, len(points))
+	for k := 1; k <= last; k++ {
+		costs[k].lines = -1
+		for m := k - 1; m >= 0; m-- {
+			width := widths[points[k].end] - widths[points[m].next]
+			if width > n {
+				break
+			}
+			if costs[m].lines < 0 {
+				continue
+			}
+			c := cost{costs[m].lines + 1, costs[m].free + (n-width)*(n-width)}
+			if costs[k].lines < 0 || c.lines < costs[k].lines || c.lines == costs[k].lines && c.free < costs[k].free {
+				costs[k], prev[k] = c, m
+			}
+		}
+	}
+	if costs[last].lines < 0 {
+		return nil, false
+	}
+	var lineEnds []int
+	for k := last; k > 0; k = prev[k] {
+		lineEnds = append(lineEnds, k)
+	}
+	parts := make([]string, 0, len(lineEnds))
+	for i := len(lineEnds) - 1; i >= 0; i-- {
+		k := lineEnds[i]
+		var part strings.Builder
+		width := 0
+		for _, unit := range units[points[prev[k]].next:points[k].end] {
+			part.WriteString(unit.text)
+			width += unit.width
+		}
+		if k < last {
+			w.fill(&part, n-width)
+			part.WriteString(w.marker)
+		}
+		parts = append(parts, part.String())
+	}
+	return parts, true
+}
+
+// fill pads a line broken at a break character with spaces, so the wrap
+// markers of a cell line up.
+func (w textWrapper) fill(part *strings.Builder, padding int) {
+	if padding > 0 && w.marker != "" && !w.noFill {
+		part.WriteString(strings.Repeat(" ", padding))
+	}
+}
+
+func (w textWrapper) isBreakChar(unit textUnit) bool {
+	return unit.r != 0 && strings.ContainsRune(w.breakChars, unit.r)
+}
+
+// truncate cuts every line of str wider than n cells according to the
+// overflow policy, replacing the removed text with an ellipsis. Color
+// sequences in the removed text are kept, so colors are still closed.
+func (w textWrapper) truncate(str string, n int) string {
+	lines := strings.Split(strings.TrimRightFunc(str, unicode.IsSpace), "\n")
+	for i, line := range lines {
+		line = strings.TrimRightFunc(line, unicode.IsSpace)
+		if displayWidth(line) > n {
+			line = truncateLine(line, n, w.overflow)
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
+
+func truncateLine(line string, n int, overflow Overflow) string {
+	units := textUnits(line)
+	available := n - displayWidth(ellipsis)
+	// text units in units[cutStart:cutEnd] are dropped.
+	cutStart, cutEnd := 0, len(units)
+	fill := func(i, step, budget int) (int, int) {
+		width := 0
+		for ; i >= 0 && i < len(units); i += step {
+			if width+units[i].width > budget {
+				break
+			}
+			width += units[i].width
+		}
+		return i, width
+	}
+	switch overflow {
+	case OverflowTruncateStart:
+		end, _ := fill(len(units)-1, -1, available)
+		cutEnd = end + 1
+	case OverflowTruncateMiddle:
+		start, width := fill(0, 1, available-available/2)
+		end, _ := fill(len(units)-1, -1, available-width)
+		cutStart, cutEnd = start, max(start, end+1)
+	default:
+		cutStart, _ = fill(0, 1, available)
+	}
+	var result strings.Builder
+	for i, unit := range units {
+		if i == cutStart {
+			result.WriteString(ellipsis)
+		}
+		if i < cutStart || i >= cutEnd || isEscape(unit.text) {
+			result.WriteString(unit.text)
+		}
+	}
+	if cutStart == len(units) {
+		result.WriteString(ellipsis)
+	}
+	return result.String()
+}
+
+func isEscape(s string) bool {
+	return strings.HasPrefix(s, "\033")
+}
+
+// clampLines limits lines to maxLines, replacing the extra ones and the
+// last one kept with an indicator of how many lines were hidden. The
+// indicator is shortened to fit width, unless width is zero. When a single
+// line is kept, the indicator follows the start of the first line.
+func clampLines(lines []string, width, maxLines int) []string {
+	if maxLines <= 0 || len(lines) <= maxLines {
+		return lines
+	}
+	if maxLines == 1 {
+		return []string{clampFirstLine(lines[0], width, len(lines)-1)}
+	}
+	hidden := len(lines) - maxLines + 1
+	indicator := fmt.Sprintf("%s (+%d lines)", ellipsis, hidden)
+	if width > 0 && displayWidth(indicator) > width {
+		indicator = fmt.Sprintf("%s+%d", ellipsis, hidden)
+		if displayWidth(indicator) > width {
+			indicator = ellipsis
+		}
+	}
+	return append(lines[:maxLines-1:maxLines-1], indicator)
+}
+
+// clampFirstLine returns line followed by an indicator of the hidden lines
+// after it, truncating line when the result is wider than width.
+func clampFirstLine(line string, width, hidden int) string {
+	line = strings.TrimRightFunc(line, unicode.IsSpace)
+	long, short := fmt.Sprintf(" (+%d lines)", hidden), fmt.Sprintf("+%d", hidden)
+	if width <= 0 {
+		return line + " " + ellipsis + long
+	}
+	for _, rest := range []string{long, short} {
+		if clamped := line + " " + ellipsis + rest; displayWidth(clamped) <= width {
+			return clamped
+		}
+	}
+	if room := width - displayWidth(short); room >= 2 {
+		// drop the spaces and wrap marker ending wrapped lines.
+		cut := strings.TrimSuffix(truncateLine(line, room, OverflowTruncateEnd), ellipsis)
+		return strings.TrimRightFunc(cut, unicode.IsSpace) + ellipsis + short
+	}
+	return ellipsis
+}
+
+// clampCell limits the lines of cell with clampLines.
+func clampCell(cell string, width, maxLines int) string {
+	if maxLines <= 0 || strings.Count(cell, "\n") < maxLines {
+		return cell
+	}
+	return strings.Join(clampLines(strings.Split(cell, "\n"), width, maxLines), "\n")
+}
+
+// clampRows returns a copy of rows with every cell limited to maxLines
+// lines, where widths holds the maximum width of each column, if any.
+func clampRows(rows rowSlice, widths []int, maxLines int) rowSlice {
+	if maxLines <= 0 {
+		return rows
+	}
+	clamped := make(rowSlice, len(rows))
+	for i, row := range rows {
+		newRow := make(Row, len(row))
+		for j, cell := range row {
+			var width int
+			if j < len(widths) {
+				width = widths[j]
+			}
+			newRow[j] = clampCell(cell, width, maxLines)
+		}
+		clamped[i] = newRow
+	}
+	return clamped
+}
+
+// clampExpandedRow limits the lines of each cell in a row expanded by
+// expandRow, where widths holds the maximum width of each column.
+func clampExpandedRow(lines [][]string, widths []int, maxLines int) [][]string {
+	if len(lines) <= maxLines {
+		return lines
+	}
+	clamped := slices.Clone(lines[:maxLines])
+	last := slices.Clone(clamped[maxLines-1])
+	for j := range last {
+		var cellLines []string
+		for _, line := range lines {
+			if j < len(line) {
+				cellLines = append(cellLines, line[j])
+			}
+		}
+		for len(cellLines) > 0 && cellLines[len(cellLines)-1] == "" {
+			cellLines = cellLines[:len(cellLines)-1]
+		}
+		if len(cellLines) > maxLines {
+			var width int
+			if j < len(widths) {
+				width = widths[j]
+			}
+			last[j] = clampLines(cellLines, width, maxLines)[maxLines-1]
+		}
+	}
+	clamped[maxLines-1] = last
+	return clamped
+}
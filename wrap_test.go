@@ -0,0 +1,246 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablecli
+
+import (
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTextWrapperBreakChars(t *testing.T) {
+	url := "https://example.com/api/v1/apps?name=app&team=admin"
+	w := textWrapper{breakChars: DefaultBreakChars, marker: DefaultWrapMarker}
+	assert.Equal(t, "https://example.         ↵\ncom/api/v1/apps?name=    ↵\napp&team=admin", w.wrap(url, 26))
+	w.breakChars = PathBreakChars
+	assert.Equal(t, "https://example.com/api/ ↵\nv1/apps?name=app&        ↵\nteam=admin", w.wrap(url, 26))
+}
+
+func TestTextWrapperMarker(t *testing.T) {
+	w := textWrapper{breakChars: DefaultBreakChars, marker: " \\"}
+	assert.Equal(t, "abc \\\ndef \\\ngh", w.wrap("abcdefgh", 5))
+	w.marker = ""
+	assert.Equal(t, "abcde\nfgh", w.wrap("abcdefgh", 5))
+	assert.Equal(t, "hello\nworld", w.wrap("hello world", 5))
+}
+
+func TestWrapperOptions(t *testing.T) {
+	table := NewTable()
+	w := table.wrapper(&RenderOptions{}, 0)
+	assert.Equal(t, textWrapper{breakChars: DefaultBreakChars, marker: DefaultWrapMarker}, w)
+
+	opts := &RenderOptions{BreakOnAny: true, BreakChars: PathBreakChars, NoWrapMarker: true}
+	w = table.wrapper(opts, 0)
+	assert.Equal(t, textWrapper{breakOnAny: true, breakChars: PathBreakChars}, w)
+
+	table.Column(1).BreakChars = IdentifierBreakChars
+	table.Column(1).WrapMarker = "»"
+	w = table.wrapper(opts, 1)
+	assert.Equal(t, textWrapper{breakOnAny: true, breakChars: IdentifierBreakChars, marker: "»"}, w)
+
+	table.Column(2).NoWrapMarker = true
+	w = table.wrapper(&RenderOptions{WrapMarker: "»"}, 2)
+	assert.Equal(t, textWrapper{breakChars: DefaultBreakChars}, w)
+}
+
+func TestStringNoWrapMarker(t *testing.T) {
+	table := NewTable()
+	table.Options = &RenderOptions{NoWrapMarker: true}
+	table.Headers = Row{"Name", "Image"}
+	table.Column(1).MaxWidth = 12
+	table.Column(1).BreakChars = IdentifierBreakChars
+	table.AddRow(Row{"app1", "registry/tsuru_app-v12"})
+	expected := `+------+------------+
+| Name | Image      |
++------+------------+
+| app1 | registry/  |
+|      | tsuru_app- |
+|      | v12        |
++------+------------+
+`
+	assert.Equal(t, expected, table.String())
+}
+
+func TestStringColumnWrapMarker(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Path"}
+	table.Column(0).MaxWidth = 10
+	table.Column(0).BreakChars = PathBreakChars
+	table.Column(0).WrapMarker = "…"
+	table.AddRow(Row{"/var/lib/tsuru/data"})
+	expected := `+------------+
+| Path       |
++------------+
+| /var/lib/… |
+| tsuru/   … |
+| data       |
++------------+
+`
+	assert.Equal(t, expected, table.String())
+}
+
+func TestTextWrapperTruncate(t *testing.T) {
+	digest := "sha256:0123456789abcdef"
+	tests := []struct {
+		overflow Overflow
+		input    string
+		n        int
+		expected string
+	}{
+		{OverflowTruncateEnd, digest, 10, "sha256:01…"},
+		{OverflowTruncateMiddle, digest, 10, "sha25…cdef"},
+		{OverflowTruncateStart, digest, 10, "…789abcdef"},
+		{OverflowTruncateEnd, "short", 10, "short"},
+		{OverflowTruncateEnd, "日本語です", 6, "日本…"},
+		{OverflowTruncateStart, "日本語です", 6, "…です"},
+		{OverflowTruncateMiddle, "first line\nsecond line", 8, "firs…ine\nseco…ine"},
+		{OverflowTruncateEnd, "\x1b[31mabcdef\x1b[0m", 4, "\x1b[31mabc…\x1b[0m"},
+		{OverflowTruncateStart, "\x1b[31mabc\x1b[0mdef", 4, "…\x1b[31m\x1b[0mdef"},
+	}
+	for _, tt := range tests {
+		w := textWrapper{overflow: tt.overflow}
+		assert.Equal(t, tt.expected, w.wrap(tt.input, tt.n), "input %q", tt.input)
+	}
+}
+
+func TestStringTruncateColumns(t *testing.T) {
+	table := NewTable()
+	table.Options = &RenderOptions{MaxTTYWidth: 40}
+	table.Headers = Row{"Name", "Image", "Path"}
+	table.Column(1).Overflow = OverflowTruncateMiddle
+	table.Column(1).MaxWidth = 12
+	table.Column(2).Overflow = OverflowTruncateStart
+	table.AddRow(Row{"app1", "sha256:0123456789abcdef", "/var/lib/tsuru/apps/app1/data"})
+	table.AddRow(Row{"app2", "latest", "/tmp"})
+	expected := `+------+--------------+----------------+
+| Name | Image        | Path           |
++------+--------------+----------------+
+| app1 | sha256…bcdef | …pps/app1/data |
+| app2 | latest       | /tmp           |
++------+--------------+----------------+
+`
+	assert.Equal(t, expected, table.String())
+}
+
+func TestClampLines(t *testing.T) {
+	lines := []string{"a", "b", "c", "d", "e"}
+	assert.Equal(t, lines, clampLines(lines, 0, 0))
+	assert.Equal(t, lines, clampLines(lines, 0, 5))
+	assert.Equal(t, []string{"a", "b", "… (+3 lines)"}, clampLines(lines, 0, 3))
+	assert.Equal(t, []string{"a", "…+4"}, clampLines(lines, 5, 2))
+	assert.Equal(t, []string{"a … (+4 lines)"}, clampLines(lines, 0, 1))
+	assert.Equal(t, []string{"a …+4"}, clampLines(lines, 5, 1))
+	assert.Equal(t, []string{"a…+4"}, clampLines([]string{"abcd", "e", "f", "g", "h"}, 4, 1))
+	assert.Equal(t, []string{"…"}, clampLines(lines, 2, 1))
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, lines)
+}
+
+func TestStringMaxLinesPerCell(t *testing.T) {
+	table := NewTable()
+	table.Options = &RenderOptions{MaxTTYWidth: 30, MaxLinesPerCell: 3}
+	table.Headers = Row{"Name", "Log"}
+	table.AddRow(Row{"app1", "line1\nline2\nline3\nline4\nline5"})
+	table.AddRow(Row{"app2", "aaaa bbbb cccc dddd eeee ffff gggg hhhh iiii jjjj kkkk"})
+	table.AddRow(Row{"app3", "short"})
+	expected := `+------+---------------------+
+| Name | Log                 |
++------+---------------------+
+| app1 | line1               |
+|      | line2               |
+|      | … (+3 lines)        |
+| app2 | aaaa bbbb cccc    ↵ |
+|      | dddd eeee ffff    ↵ |
+|      | … (+2 lines)        |
+| app3 | short               |
++------+---------------------+
+`
+	assert.Equal(t, expected, table.String())
+}
+
+func TestStringSingleLinePerCell(t *testing.T) {
+	table := NewTable()
+	table.Options = &RenderOptions{MaxTTYWidth: 30, MaxLinesPerCell: 1}
+	table.Headers = Row{"Name", "Log"}
+	table.AddRow(Row{"app1", "line1\nline2\nline3"})
+	table.AddRow(Row{"app2", "aaaa bbbb cccc dddd eeee ffff gggg hhhh iiii jjjj kkkk"})
+	table.AddRow(Row{"app3", "short"})
+	expected := `+------+---------------------+
+| Name | Log                 |
++------+---------------------+
+| app1 | line1 … (+2 lines)  |
+| app2 | aaaa bbbb cccc…+3   |
+| app3 | short               |
++------+---------------------+
+`
+	assert.Equal(t, expected, table.String())
+}
+
+func TestStringTabWriterMaxLinesPerCell(t *testing.T) {
+	table := NewTable()
+	table.Options = &RenderOptions{UseTabWriter: true, MaxLinesPerCell: 2}
+	table.TableWriterExpandRows = true
+	table.Headers = Row{"Name", "Env"}
+	table.AddRow(Row{"app1", "A=1\nB=2\nC=3\nD=4"})
+	table.AddRow(Row{"app2\nextra", "E=5"})
+	expected := "NAME    ENV\n" +
+		"app1    A=1\n" +
+		"        … (+3 lines)\n" +
+		"app2    E=5\n" +
+		"extra   \n"
+	assert.Equal(t, expected, table.String())
+}
+
+func TestExpandedMaxLinesPerCell(t *testing.T) {
+	table := NewTable()
+	table.Options = &RenderOptions{Expanded: true, MaxLinesPerCell: 2}
+	table.Headers = Row{"Name", "Env"}
+	table.AddRow(Row{"app1", "A=1\nB=2\nC=3"})
+	expected := `-[ RECORD 1 ]
+Name | app1
+Env  | A=1
+     | … (+2 lines)
+`
+	assert.Equal(t, expected, table.String())
+}
+
+func TestTextWrapperBalanced(t *testing.T) {
+	text := "aaa bb cc ddddd"
+	w := textWrapper{breakChars: DefaultBreakChars, marker: DefaultWrapMarker}
+	assert.Equal(t, "aaa bb cc↵\nddddd", w.wrap(text, 10))
+	w.balanced = true
+	assert.Equal(t, "aaa bb   ↵\ncc ddddd", w.wrap(text, 10))
+	assert.Equal(t, "a.b.c. ↵\nd.e.f", w.wrap("a.b.c.d.e.f", 8))
+	assert.Equal(t, "abcdefg↵\nhij", w.wrap("abcdefghij", 8))
+	w.marker = ""
+	assert.Equal(t, "the quick brown\nfox jumps over", w.wrap("the quick brown fox jumps over", 15))
+}
+
+func TestTextWrapperBalancedWithColors(t *testing.T) {
+	color.NoColor = false
+	defer func() { color.NoColor = true }()
+	red := color.New(color.FgRed).SprintFunc()
+	w := textWrapper{breakChars: DefaultBreakChars, balanced: true}
+	result := w.wrap(red("aaa bb cc ddddd"), 9)
+	assert.Equal(t, "\x1b[31maaa bb\x1b[0m\n\x1b[31mcc ddddd\x1b[0m", result)
+	result = w.wrap(red("aaa")+" bb "+red("cc")+" ddddd", 9)
+	assert.Equal(t, "\x1b[31maaa\x1b[0m bb\n\x1b[31mcc\x1b[0m ddddd", result)
+}
+
+func TestStringBalancedWrapColumn(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name", "Description"}
+	table.Column(1).MaxWidth = 25
+	table.Column(1).BalancedWrap = true
+	table.AddRow(Row{"app1", "a small application used for testing"})
+	expected := `+------+---------------------------+
+| Name | Description               |
++------+---------------------------+
+| app1 | a small application     ↵ |
+|      | used for testing          |
++------+---------------------------+
+`
+	assert.Equal(t, expected, table.String())
+}
@@ -0,0 +1,148 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablecli
+
+import (
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// yamlReservedPattern matches plain scalars that YAML 1.1 or 1.2 parsers
+// would resolve to something other than a string: booleans, nulls,
+// numbers in any base, infinities, timestamps and the "<<" merge key.
+var yamlReservedPattern = regexp.MustCompile(`^(?i:y|n|yes|no|true|false|on|off|null|~|<<|[-+]?\.inf|\.nan)$|^[-+]?[.0-9]|^\d{4}-\d\d?-\d\d?`)
+
+// RenderYAML writes the table to w as a YAML sequence of mappings keyed by
+// Headers, or a sequence of sequences when the table has no headers.
+// Colors are stripped, multiline cells are written as block scalars and
+// values that would not be read back as strings are quoted. Title and
+// Caption are written as comments and footer rows are left out.
+func (t *Table) RenderYAML(w io.Writer) error {
+	buf := &lineWriter{w: w}
+	writeYAMLComment(buf, t.Title)
+	if len(t.rows) == 0 {
+		buf.WriteString("[]\n")
+		writeYAMLComment(buf, t.Caption)
+		return buf.flush()
+	}
+	headers := t.exportHeaders()
+	for _, row := range t.rows {
+		if buf.err != nil {
+			break
+		}
+		buf.WriteString(yamlRecord(headers, t.exportRow(row)))
+	}
+	writeYAMLComment(buf, t.Caption)
+	return buf.flush()
+}
+
+func yamlRecord(headers, cells []string) string {
+	var b strings.Builder
+	for i, cell := range cells {
+		if i == 0 {
+			b.WriteString("- ")
+		} else {
+			b.WriteString("  ")
+		}
+		if len(headers) == 0 {
+			b.WriteString("-")
+		} else {
+			b.WriteString(yamlScalar(headers[i]))
+			b.WriteString(":")
+		}
+		writeYAMLValue(&b, cell, "    ")
+	}
+	if len(cells) == 0 {
+		if len(headers) == 0 {
+			b.WriteString("- []\n")
+		} else {
+			b.WriteString("- {}\n")
+		}
+	}
+	return b.String()
+}
+
+// writeYAMLValue writes s after a mapping key or sequence indicator, as a
+// block scalar indented by indent when it spans several lines.
+func writeYAMLValue(b *strings.Builder, s, indent string) {
+	if !yamlBlockable(s) {
+		b.WriteString(" ")
+		b.WriteString(yamlScalar(s))
+		b.WriteString("\n")
+		return
+	}
+	content := strings.TrimRight(s, "\n")
+	switch trailing := len(s) - len(content); {
+	case trailing == 0:
+		b.WriteString(" |-\n")
+	case trailing == 1:
+		b.WriteString(" |\n")
+	default:
+		b.WriteString(" |+\n")
+	}
+	for _, line := range strings.Split(s[:len(s)-min(len(s)-len(content), 1)], "\n") {
+		if line != "" {
+			b.WriteString(indent)
+			b.WriteString(line)
+		}
+		b.WriteString("\n")
+	}
+}
+
+// yamlBlockable reports whether s can be written as a literal block scalar
+// without losing information. The first line must not start with
+// whitespace, which parsers would take as indentation.
+func yamlBlockable(s string) bool {
+	if !strings.Contains(s, "\n") || strings.HasPrefix(s, "\n") || strings.HasPrefix(s, " ") || strings.HasPrefix(s, "\t") {
+		return false
+	}
+	for _, r := range s {
+		if r != '\n' && r != '\t' && !unicode.IsPrint(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// yamlScalar returns s as a plain scalar when it is safe to do so, or as
+// a double-quoted scalar otherwise.
+func yamlScalar(s string) string {
+	if yamlNeedsQuote(s) {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+func yamlNeedsQuote(s string) bool {
+	if s == "" || yamlReservedPattern.MatchString(s) {
+		return true
+	}
+	if strings.ContainsRune("-?:,[]{}#&*!|>'\"%@`", rune(s[0])) {
+		return true
+	}
+	if strings.TrimSpace(s) != s || strings.HasSuffix(s, ":") ||
+		strings.Contains(s, ": ") || strings.Contains(s, " #") {
+		return true
+	}
+	for _, r := range s {
+		if !unicode.IsPrint(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeYAMLComment writes every line of text as a YAML comment.
+func writeYAMLComment(buf *lineWriter, text string) {
+	if text == "" {
+		return
+	}
+	for _, line := range strings.Split(stripANSI(text), "\n") {
+		buf.WriteString(strings.TrimRight("# "+line, " ") + "\n")
+	}
+}
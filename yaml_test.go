@@ -0,0 +1,153 @@
+// Copyright 2026 tsuru authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablecli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRenderYAML(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name", "Units"}
+	table.AddRow(Row{"app1", "2"})
+	table.AddRow(Row{"app2", "10"})
+	var buf strings.Builder
+	err := table.RenderYAML(&buf)
+	assert.NoError(t, err)
+	expected := `- Name: app1
+  Units: "2"
+- Name: app2
+  Units: "10"
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestRenderYAMLWithoutHeaders(t *testing.T) {
+	table := NewTable()
+	table.AddRow(Row{"One", "1"})
+	table.AddRow(Row{"Two", "a\nb"})
+	var buf strings.Builder
+	err := table.RenderYAML(&buf)
+	assert.NoError(t, err)
+	expected := `- - One
+  - "1"
+- - Two
+  - |-
+    a
+    b
+`
+	assert.Equal(t, expected, buf.String())
+	var decoded [][]string
+	assert.NoError(t, yaml.Unmarshal([]byte(buf.String()), &decoded))
+	assert.Equal(t, [][]string{{"One", "1"}, {"Two", "a\nb"}}, decoded)
+}
+
+func TestRenderYAMLEmpty(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name"}
+	var buf strings.Builder
+	err := table.RenderYAML(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "[]\n", buf.String())
+}
+
+func TestRenderYAMLMultiline(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name", "Env"}
+	table.AddRow(Row{withColor("app1"), "A=1\n\nB=2"})
+	table.AddRow(Row{"app2", "A=1\n"})
+	table.AddRow(Row{"app3", "A=1\n\n"})
+	var buf strings.Builder
+	err := table.RenderYAML(&buf)
+	assert.NoError(t, err)
+	expected := `- Name: app1
+  Env: |-
+    A=1
+
+    B=2
+- Name: app2
+  Env: |
+    A=1
+- Name: app3
+  Env: |+
+    A=1
+
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestRenderYAMLRoundTrip(t *testing.T) {
+	values := []string{
+		"yes", "No", "ON", "off", "y", "n", "true", "False", "null", "Null", "~", "",
+		"0123", "123", "-1", "+1", "1.5", ".5", "1e3", "0x1F", "0o17", ".inf", "-.Inf", ".NaN",
+		"2001-12-14", "12:30:00", "- item", "? key", ": value", "key: value", "a #comment",
+		"ends with:", "[list]", "{map}", "#hash", "&anchor", "*alias", "!tag", "|pipe",
+		">fold", "'single'", `"double"`, "%percent", "@at", "`tick`", " leading", "trailing ",
+		"tab\there", "cr\rhere", "bell\a", "\nleading newline", " indented\nblock",
+		"\tindented\nlog output", "<<", "multi\nline", "trailing\n", "trailing\n\n\n", "plain text", "ünïcödé", "a:b", "a#b",
+	}
+	table := NewTable()
+	table.Headers = Row{"Value", "true", "1"}
+	for _, v := range values {
+		table.AddRow(Row{v, v, v})
+	}
+	var buf strings.Builder
+	err := table.RenderYAML(&buf)
+	assert.NoError(t, err)
+	var decoded []map[string]string
+	err = yaml.Unmarshal([]byte(buf.String()), &decoded)
+	assert.NoError(t, err, buf.String())
+	if assert.Len(t, decoded, len(values)) {
+		for i, v := range values {
+			assert.Equal(t, map[string]string{"Value": v, "true": v, "1": v}, decoded[i])
+		}
+	}
+	var untyped []map[string]any
+	err = yaml.Unmarshal([]byte(buf.String()), &untyped)
+	assert.NoError(t, err)
+	for i, v := range values {
+		assert.Equal(t, v, untyped[i]["Value"], "value %q changed type", v)
+	}
+}
+
+func TestYAMLNeedsQuote(t *testing.T) {
+	assert.False(t, yamlNeedsQuote("app1"))
+	assert.False(t, yamlNeedsQuote("my app"))
+	assert.False(t, yamlNeedsQuote("http://host:8080/path"))
+	assert.True(t, yamlNeedsQuote("yes"))
+	assert.True(t, yamlNeedsQuote("0123"))
+	assert.True(t, yamlNeedsQuote("null"))
+	assert.True(t, yamlNeedsQuote(""))
+	assert.True(t, yamlNeedsQuote("<<"))
+}
+
+func TestRenderYAMLMergeKeyHeader(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"<<", "Name"}
+	table.AddRow(Row{"\tat main.go:10\n\tat app.go:3", "app1"})
+	var buf strings.Builder
+	err := table.RenderYAML(&buf)
+	assert.NoError(t, err)
+	var decoded []map[string]string
+	err = yaml.Unmarshal([]byte(buf.String()), &decoded)
+	assert.NoError(t, err, buf.String())
+	assert.Equal(t, []map[string]string{{"<<": "\tat main.go:10\n\tat app.go:3", "Name": "app1"}}, decoded)
+}
+
+func TestRenderYAMLWriteError(t *testing.T) {
+	table := NewTable()
+	table.Headers = Row{"Name"}
+	for i := 0; i < 10; i++ {
+		table.AddRow(Row{"One"})
+	}
+	w := &failWriter{failAfter: 2}
+	err := table.RenderYAML(w)
+	assert.EqualError(t, err, "write failed")
+	assert.Equal(t, 2, w.writes)
+}